@@ -0,0 +1,293 @@
+package maidenhead
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AntennaPattern is an antenna's gain in dBi by azimuth off boresight and elevation, loaded from a pattern table.
+// Gains between samples are interpolated linearly in dB. Create one with ParseAntennaPattern or
+// LoadAntennaPatternFile; the zero value has no samples and behaves as an isotropic antenna.
+type AntennaPattern struct {
+	cuts []patternCut // Ordered by elevation
+	peak float64
+}
+
+// patternCut holds the samples at one elevation angle, ordered by azimuth in [0, 360).
+type patternCut struct {
+	elevation float64
+	azimuths  []float64
+	gains     []float64
+}
+
+// TargetGain is the antenna gain towards one target locator.
+type TargetGain struct {
+	GridSquare string  `json:"gridSquare"`
+	Bearing    float64 `json:"bearing"`
+	// OffAxis is the angle of the target from the antenna heading, -180 to 180 degrees with positive values clockwise.
+	OffAxis    float64 `json:"off_axis"`
+	GainDBi    float64 `json:"gain_dbi"`
+	RelativeDB float64 `json:"relative_db"` // Gain relative to the pattern's peak, 0 or negative
+}
+
+// LoadAntennaPatternFile reads an antenna pattern table from a file on disk; see ParseAntennaPattern for the formats.
+func LoadAntennaPatternFile(path string) (*AntennaPattern, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open antenna pattern file: %w", err)
+	}
+	defer f.Close()
+	return ParseAntennaPattern(f)
+}
+
+// ParseAntennaPattern reads an antenna pattern table from r. Each data row holds numbers separated by commas,
+// semicolons or white space: either azimuth and gain, or azimuth, elevation and gain, with azimuth in degrees off
+// boresight and gain in dBi. A header row naming the columns (az/azimuth/deg, el/elevation, gain/dBi/tot) selects
+// columns in wider tables, so the azimuth tables exported by MMANA-GAL and EZNEC can be read directly; an EZNEC
+// "Elevation Angle = 10.0 deg." line sets the elevation of the rows that follow. Blank lines, lines starting with #
+// and other text lines are ignored.
+func ParseAntennaPattern(r io.Reader) (*AntennaPattern, error) {
+	var (
+		azCol, elCol, gainCol = 0, -1, -1
+		elevation             float64
+		cuts                  = map[float64]*patternCut{}
+	)
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := splitPatternLine(line)
+		values, ok := parsePatternValues(fields)
+		if !ok {
+			lower := strings.ToLower(line)
+			if i := strings.Index(lower, "elevation angle"); i >= 0 {
+				if v, ok := leadingNumber(lower[i+len("elevation angle"):]); ok {
+					elevation = v
+				}
+				continue
+			}
+			if az, el, gain, ok := patternHeader(fields); ok {
+				azCol, elCol, gainCol = az, el, gain
+			}
+			continue
+		}
+
+		for _, v := range values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil, fmt.Errorf("line %d: invalid value: %v", lineNum, v)
+			}
+		}
+
+		var az, el, gain float64
+		switch {
+		case gainCol >= 0:
+			if len(values) <= max(azCol, elCol, gainCol) {
+				return nil, fmt.Errorf("line %d: expected at least %d columns", lineNum, max(azCol, elCol, gainCol)+1)
+			}
+			az, el, gain = values[azCol], elevation, values[gainCol]
+			if elCol >= 0 {
+				el = values[elCol]
+			}
+		case len(values) == 2:
+			az, el, gain = values[0], elevation, values[1]
+		case len(values) == 3:
+			az, el, gain = values[0], values[1], values[2]
+		default:
+			return nil, fmt.Errorf("line %d: expected 2 or 3 columns", lineNum)
+		}
+		if el < -90 || el > 90 {
+			return nil, fmt.Errorf("line %d: invalid elevation: %v", lineNum, el)
+		}
+		cut := cuts[el]
+		if cut == nil {
+			cut = &patternCut{elevation: el}
+			cuts[el] = cut
+		}
+		cut.azimuths = append(cut.azimuths, math.Mod(math.Mod(az, 360)+360, 360))
+		cut.gains = append(cut.gains, gain)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read antenna pattern: %w", err)
+	}
+	if len(cuts) == 0 {
+		return nil, fmt.Errorf("invalid antenna pattern: no data rows")
+	}
+
+	p := &AntennaPattern{peak: math.Inf(-1)}
+	for _, cut := range cuts {
+		cut.sort()
+		p.cuts = append(p.cuts, *cut)
+		p.peak = math.Max(p.peak, slices.Max(cut.gains))
+	}
+	slices.SortFunc(p.cuts, func(a, b patternCut) int { return cmp.Compare(a.elevation, b.elevation) })
+	return p, nil
+}
+
+// PeakGain returns the highest gain in the pattern in dBi.
+func (p *AntennaPattern) PeakGain() float64 {
+	return p.peak
+}
+
+// Gain returns the gain in dBi at an azimuth off boresight (positive clockwise) and elevation, both in degrees.
+// Elevations outside the range of the pattern use the nearest elevation cut, so a pattern with a single cut ignores
+// elevation. A pattern with no samples returns 0.
+func (p *AntennaPattern) Gain(offAxis, elevation float64) float64 {
+	if len(p.cuts) == 0 {
+		return 0
+	}
+	az := math.Mod(math.Mod(offAxis, 360)+360, 360)
+	i, _ := slices.BinarySearchFunc(p.cuts, elevation, func(c patternCut, el float64) int {
+		return cmp.Compare(c.elevation, el)
+	})
+	switch {
+	case i == 0:
+		return p.cuts[0].gain(az)
+	case i == len(p.cuts):
+		return p.cuts[i-1].gain(az)
+	}
+	lo, hi := p.cuts[i-1], p.cuts[i]
+	frac := (elevation - lo.elevation) / (hi.elevation - lo.elevation)
+	return lo.gain(az) + (hi.gain(az)-lo.gain(az))*frac
+}
+
+// GetTargetGains calculates the antenna gain from a local station towards each target locator for a given antenna
+// heading, using the bearings from GetShortPathBearing, and returns the targets ranked by gain. Grid square input is
+// case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead Grid Square of the local station (6 characters)
+//   - heading: The direction the antenna is pointing in degrees from true north
+//   - elevation: The elevation angle in degrees at which to read the pattern, e.g. the takeoff angle
+//   - targets: The Maidenhead Grid Squares of the targets (6 characters)
+//
+// Returns:
+//   - []TargetGain: The gain towards each target, highest first; targets with equal gain keep their input order
+//   - error: An error if any grid square is invalid
+func (p *AntennaPattern) GetTargetGains(localGridSquare string, heading, elevation float64, targets []string) ([]TargetGain, error) {
+	gains := make([]TargetGain, 0, len(targets))
+	for i, target := range targets {
+		bearing, err := GetShortPathBearing(localGridSquare, target)
+		if err != nil {
+			return nil, fmt.Errorf("target %d: %w", i+1, err)
+		}
+		offAxis := offAxisAngle(bearing, heading)
+		gain := p.Gain(offAxis, elevation)
+		gains = append(gains, TargetGain{
+			GridSquare: target,
+			Bearing:    bearing,
+			OffAxis:    math.Round(offAxis*10) / 10,
+			GainDBi:    math.Round(gain*100) / 100,
+			RelativeDB: math.Round((gain-p.peak)*100) / 100,
+		})
+	}
+	slices.SortStableFunc(gains, func(a, b TargetGain) int { return cmp.Compare(b.GainDBi, a.GainDBi) })
+	return gains, nil
+}
+
+// offAxisAngle returns the angle of bearing from heading, -180 to 180 degrees with positive values clockwise.
+func offAxisAngle(bearing, heading float64) float64 {
+	return math.Mod(math.Mod(bearing-heading+540, 360)+360, 360) - 180
+}
+
+// sort orders the cut's samples by azimuth, dropping repeated azimuths such as 0 and 360.
+func (c *patternCut) sort() {
+	idx := make([]int, len(c.azimuths))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(a, b int) int { return cmp.Compare(c.azimuths[a], c.azimuths[b]) })
+	azimuths, gains := make([]float64, 0, len(idx)), make([]float64, 0, len(idx))
+	for _, i := range idx {
+		if n := len(azimuths); n > 0 && azimuths[n-1] == c.azimuths[i] {
+			continue
+		}
+		azimuths = append(azimuths, c.azimuths[i])
+		gains = append(gains, c.gains[i])
+	}
+	c.azimuths, c.gains = azimuths, gains
+}
+
+// gain interpolates the cut at azimuth az in [0, 360), wrapping around between the last and first samples.
+func (c *patternCut) gain(az float64) float64 {
+	n := len(c.azimuths)
+	i, found := slices.BinarySearch(c.azimuths, az)
+	if found || n == 1 {
+		return c.gains[i%n]
+	}
+	prev, next := (i+n-1)%n, i%n
+	span := math.Mod(c.azimuths[next]-c.azimuths[prev]+360, 360)
+	frac := math.Mod(az-c.azimuths[prev]+360, 360) / span
+	return c.gains[prev] + (c.gains[next]-c.gains[prev])*frac
+}
+
+// splitPatternLine splits a pattern table line on commas or semicolons if it has any, otherwise on white space.
+func splitPatternLine(line string) []string {
+	var fields []string
+	if strings.ContainsAny(line, ",;") {
+		fields = strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ';' })
+	} else {
+		fields = strings.Fields(line)
+	}
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
+// parsePatternValues parses every field as a number, reporting false if any is not.
+func parsePatternValues(fields []string) ([]float64, bool) {
+	values := make([]float64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, false
+		}
+		values[i] = v
+	}
+	return values, true
+}
+
+// patternHeader finds the azimuth, elevation and gain columns named in a header row. Bare "dB" unit fields, as in
+// EZNEC's "Deg V dB H dB Tot dB", are not columns and are skipped. The elevation column is -1 if absent.
+func patternHeader(fields []string) (int, int, int, bool) {
+	az, el, gain := -1, -1, -1
+	col := 0
+	for _, f := range fields {
+		name := strings.ToLower(f)
+		if name == "db" {
+			continue
+		}
+		switch {
+		case az < 0 && (strings.HasPrefix(name, "az") || name == "deg" || name == "phi"):
+			az = col
+		case el < 0 && strings.HasPrefix(name, "el"):
+			el = col
+		case gain < 0 && (strings.Contains(name, "gain") || strings.Contains(name, "dbi") || strings.HasPrefix(name, "tot")):
+			gain = col
+		}
+		col++
+	}
+	return az, el, gain, az >= 0 && gain >= 0
+}
+
+// leadingNumber parses the first number in s after any spaces and an optional "=" sign.
+func leadingNumber(s string) (float64, bool) {
+	s = strings.TrimLeft(s, " =\t")
+	end := strings.IndexFunc(s, func(r rune) bool { return !strings.ContainsRune("+-.0123456789", r) })
+	if end < 0 {
+		end = len(s)
+	}
+	v, err := strconv.ParseFloat(strings.TrimRight(s[:end], "."), 64)
+	return v, err == nil
+}
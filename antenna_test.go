@@ -0,0 +1,185 @@
+package maidenhead
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const yagiPatternCSV = `# Simple Yagi, azimuth vs gain
+azimuth,gain
+0,10
+90,-10
+180,-5
+270,-10
+360,10
+`
+
+const eznecPattern = `EZNEC+ ver. 5.0
+
+3 el Yagi     10/18/2026     12:00:00
+--------------- FAR FIELD ---------------
+
+Frequency = 14.2 MHz
+
+Azimuth Plot                         Cursor Az = 0.0 deg.
+Elevation Angle = 10.0 deg.          Gain = 8.0 dBi
+Outer Ring = 8.0 dBi
+
+  Deg      V dB      H dB      Tot dB
+   0.0   -99.99      8.00       8.00
+  90.0   -99.99     -6.00      -6.00
+ 180.0   -99.99     -12.00    -12.00
+ 270.0   -99.99     -6.00      -6.00
+
+Elevation Angle = 30.0 deg.
+
+  Deg      V dB      H dB      Tot dB
+   0.0   -99.99      4.00       4.00
+  90.0   -99.99     -8.00      -8.00
+ 180.0   -99.99     -14.00    -14.00
+ 270.0   -99.99     -8.00      -8.00
+`
+
+func TestParseAntennaPatternCSV(t *testing.T) {
+	p, err := ParseAntennaPattern(strings.NewReader(yagiPatternCSV))
+	if err != nil {
+		t.Fatalf("ParseAntennaPattern error: %v", err)
+	}
+	if p.PeakGain() != 10 {
+		t.Errorf("peak got %v want 10", p.PeakGain())
+	}
+	tests := []struct {
+		offAxis, want float64
+	}{
+		{0, 10},
+		{45, 0},
+		{-45, 0},
+		{315, 0},
+		{135, -7.5},
+		{180, -5},
+		{540, -5},
+	}
+	for _, tt := range tests {
+		// A single cut ignores elevation
+		if got := p.Gain(tt.offAxis, 25); !almostEqual(got, tt.want, 1e-9) {
+			t.Errorf("Gain(%v) got %v want %v", tt.offAxis, got, tt.want)
+		}
+	}
+}
+
+func TestParseAntennaPatternElevation(t *testing.T) {
+	// Three columns without a header: azimuth, elevation, gain
+	p, err := ParseAntennaPattern(strings.NewReader("0 0 6\n180 0 -6\n0 20 10\n180 20 -10\n"))
+	if err != nil {
+		t.Fatalf("ParseAntennaPattern error: %v", err)
+	}
+	tests := []struct {
+		offAxis, elevation, want float64
+	}{
+		{0, 0, 6},
+		{0, 10, 8},
+		{0, 20, 10},
+		{0, 45, 10},
+		{0, -5, 6},
+		{90, 10, 0},
+		{180, 10, -8},
+	}
+	for _, tt := range tests {
+		if got := p.Gain(tt.offAxis, tt.elevation); !almostEqual(got, tt.want, 1e-9) {
+			t.Errorf("Gain(%v, %v) got %v want %v", tt.offAxis, tt.elevation, got, tt.want)
+		}
+	}
+}
+
+func TestParseAntennaPatternEZNEC(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "yagi.txt")
+	if err := os.WriteFile(path, []byte(eznecPattern), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadAntennaPatternFile(path)
+	if err != nil {
+		t.Fatalf("LoadAntennaPatternFile error: %v", err)
+	}
+	if p.PeakGain() != 8 {
+		t.Errorf("peak got %v want 8", p.PeakGain())
+	}
+	if got := p.Gain(0, 10); got != 8 {
+		t.Errorf("Gain(0, 10) got %v want 8 from the Tot dB column", got)
+	}
+	if got := p.Gain(180, 20); !almostEqual(got, -13, 1e-9) {
+		t.Errorf("Gain(180, 20) got %v want -13", got)
+	}
+}
+
+func TestParseAntennaPatternErrors(t *testing.T) {
+	tests := []struct {
+		name, data string
+	}{
+		{"no data", "# nothing here\n"},
+		{"too many columns", "0 1 2 3\n"},
+		{"invalid elevation", "0,95,10\n"},
+		{"short row for header", "az,el,gain\n0,10\n"},
+		{"NaN gain", "0,10\n90,NaN\n"},
+		{"infinite azimuth", "0,10\nInf,5\n"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseAntennaPattern(strings.NewReader(tt.data)); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	if _, err := ParseAntennaPattern(strings.NewReader("0,10\n90,NaN\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected line-numbered error, got %v", err)
+	}
+	if _, err := LoadAntennaPatternFile(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestAntennaPatternZeroValue(t *testing.T) {
+	var p AntennaPattern
+	if got := p.Gain(45, 10); got != 0 {
+		t.Errorf("zero value Gain got %v want 0", got)
+	}
+	gains, err := p.GetTargetGains("JN58td", 90, 0, []string{"JN68td"})
+	if err != nil {
+		t.Fatalf("GetTargetGains error: %v", err)
+	}
+	if gains[0].GainDBi != 0 || gains[0].RelativeDB != 0 {
+		t.Errorf("zero value target gain got %+v", gains[0])
+	}
+}
+
+func TestGetTargetGains(t *testing.T) {
+	p, err := ParseAntennaPattern(strings.NewReader(yagiPatternCSV))
+	if err != nil {
+		t.Fatalf("ParseAntennaPattern error: %v", err)
+	}
+	// From JN58td: JN68td lies roughly east, JN38td roughly west and JO58td due north
+	targets := []string{"JO58td", "JN38td", "jn68td"}
+	gains, err := p.GetTargetGains("JN58td", 90, 0, targets)
+	if err != nil {
+		t.Fatalf("GetTargetGains error: %v", err)
+	}
+	order := []string{"jn68td", "JN38td", "JO58td"}
+	for i, g := range gains {
+		if g.GridSquare != order[i] {
+			t.Errorf("rank %d got %s want %s", i+1, g.GridSquare, order[i])
+		}
+	}
+	east := gains[0]
+	if bearing, _ := GetShortPathBearing("JN58td", "JN68td"); east.Bearing != bearing {
+		t.Errorf("bearing got %v want %v", east.Bearing, bearing)
+	}
+	if !almostEqual(east.OffAxis, east.Bearing-90, 0.05) || east.GainDBi < 9 || east.RelativeDB > 0 || east.RelativeDB < -1 {
+		t.Errorf("unexpected gain towards the east: %+v", east)
+	}
+	if north := gains[2]; !almostEqual(north.OffAxis, -90, 0.1) || !almostEqual(north.GainDBi, -10, 0.1) {
+		t.Errorf("unexpected gain towards the north: %+v", north)
+	}
+
+	if _, err := p.GetTargetGains("JN58td", 0, 0, []string{"JN68td", "bad"}); err == nil {
+		t.Error("expected error for invalid target")
+	}
+}
@@ -0,0 +1,81 @@
+package maidenhead
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Band is an amateur radio band.
+type Band int
+
+const (
+	Band160m Band = iota
+	Band80m
+	Band60m
+	Band40m
+	Band30m
+	Band20m
+	Band17m
+	Band15m
+	Band12m
+	Band10m
+	Band6m
+	Band4m
+	Band2m
+	Band70cm
+	Band23cm
+)
+
+// bandInfo holds the name and representative frequency (MHz) of each band, indexed by Band.
+var bandInfo = []struct {
+	name         string
+	frequencyMHz float64
+}{
+	Band160m: {"160m", 1.85},
+	Band80m:  {"80m", 3.65},
+	Band60m:  {"60m", 5.35},
+	Band40m:  {"40m", 7.1},
+	Band30m:  {"30m", 10.12},
+	Band20m:  {"20m", 14.15},
+	Band17m:  {"17m", 18.1},
+	Band15m:  {"15m", 21.2},
+	Band12m:  {"12m", 24.94},
+	Band10m:  {"10m", 28.5},
+	Band6m:   {"6m", 50.2},
+	Band4m:   {"4m", 70.2},
+	Band2m:   {"2m", 144.2},
+	Band70cm: {"70cm", 432.2},
+	Band23cm: {"23cm", 1296.2},
+}
+
+// ParseBand parses a band name such as "20m" or "70CM" (case-insensitive).
+func ParseBand(s string) (Band, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for b, info := range bandInfo {
+		if info.name == name {
+			return Band(b), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown band: %q", s)
+}
+
+// Valid reports whether b is one of the defined bands.
+func (b Band) Valid() bool {
+	return b >= Band160m && b <= Band23cm
+}
+
+// String returns the band name, e.g. "20m".
+func (b Band) String() string {
+	if !b.Valid() {
+		return fmt.Sprintf("Band(%d)", int(b))
+	}
+	return bandInfo[b].name
+}
+
+// FrequencyMHz returns a representative frequency in the band, near the usual weak-signal and DX segments.
+func (b Band) FrequencyMHz() float64 {
+	if !b.Valid() {
+		return 0
+	}
+	return bandInfo[b].frequencyMHz
+}
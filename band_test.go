@@ -0,0 +1,43 @@
+package maidenhead
+
+import "testing"
+
+func TestParseBand(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Band
+		wantErr bool
+	}{
+		{"20m", Band20m, false},
+		{"70CM", Band70cm, false},
+		{" 6m ", Band6m, false},
+		{"160m", Band160m, false},
+		{"11m", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseBand(tt.in)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("ParseBand(%q) got %v, %v want %v (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestBand(t *testing.T) {
+	prev := 0.0
+	for b := Band160m; b <= Band23cm; b++ {
+		if !b.Valid() {
+			t.Errorf("%d should be valid", b)
+		}
+		if b.FrequencyMHz() <= prev {
+			t.Errorf("%s frequency %.2f not above previous band", b, b.FrequencyMHz())
+		}
+		prev = b.FrequencyMHz()
+		if parsed, err := ParseBand(b.String()); err != nil || parsed != b {
+			t.Errorf("round trip of %s got %v, %v", b, parsed, err)
+		}
+	}
+	if Band(99).Valid() || Band(99).String() != "Band(99)" || Band(-1).FrequencyMHz() != 0 {
+		t.Errorf("invalid band not handled")
+	}
+}
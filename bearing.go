@@ -5,6 +5,7 @@ import (
 	"math"
 	"strconv"
 	"unicode"
+	"unicode/utf8"
 )
 
 const (
@@ -179,26 +180,29 @@ func GetShortPathDistance(localGridSquare, remoteGridSquare string) (float64, fl
 		return 0.0, 0.0, fmt.Errorf("invalid remote grid square: %w", err)
 	}
 
+	// Calculate distances in kilometers and miles
+	distanceKm := math.Ceil(haversineKm(localCoords.Latitude, localCoords.Longitude, remoteCoords.Latitude, remoteCoords.Longitude))
+	distanceMiles := math.Ceil(distanceKm * kmToMiles)
+
+	return distanceKm, distanceMiles, nil
+}
+
+// haversineKm returns the unrounded great-circle distance in kilometers between two points given in degrees.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
 	// Convert coordinates to radians for calculation
-	localLongRad := toRadians(localCoords.Longitude)
-	localLatRad := toRadians(localCoords.Latitude)
-	remoteLongRad := toRadians(remoteCoords.Longitude)
-	remoteLatRad := toRadians(remoteCoords.Latitude)
+	lat1Rad := toRadians(lat1)
+	lat2Rad := toRadians(lat2)
 
 	// Calculate differences in coordinates
-	dLat := remoteLatRad - localLatRad
-	dLon := remoteLongRad - localLongRad
+	dLat := lat2Rad - lat1Rad
+	dLon := toRadians(lon2) - toRadians(lon1)
 
 	// Haversine formula for great-circle distance
 	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
-		math.Cos(localLatRad)*math.Cos(remoteLatRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+		math.Cos(lat1Rad)*math.Cos(lat2Rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
 	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
 
-	// Calculate distances in kilometers and miles
-	distanceKm := math.Ceil(earthRad * c)
-	distanceMiles := math.Ceil(distanceKm * kmToMiles)
-
-	return distanceKm, distanceMiles, nil
+	return earthRad * c
 }
 
 func GetLongPathDistance(localGridSquare, remoteGridSquare string) (float64, float64, error) {
@@ -229,6 +233,12 @@ func GetLongPathDistance(localGridSquare, remoteGridSquare string) (float64, flo
 //   - float64: The initial bearing in degrees from the starting point to the destination (0-360°),
 //     rounded to the nearest 0.1 degree
 func CalculateBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	// Round to the nearest 0.1 degree
+	return math.Round(rawBearing(lat1, lon1, lat2, lon2)*10) / 10
+}
+
+// rawBearing returns the unrounded initial great-circle bearing in degrees (0-360°) between two points.
+func rawBearing(lat1, lon1, lat2, lon2 float64) float64 {
 	// Convert degrees to radians
 	lat1Rad := toRadians(lat1)
 	lon1Rad := toRadians(lon1)
@@ -252,8 +262,7 @@ func CalculateBearing(lat1, lon1, lat2, lon2 float64) float64 {
 		initialBearing += 360
 	}
 
-	// Round to the nearest 0.1 degree
-	return math.Round(initialBearing*10) / 10
+	return initialBearing
 	//bearing, err := strconv.ParseFloat(fmt.Sprintf("%.1f", initialBearing), 64)
 	//if err != nil {
 	//	return 0.0 // Return 0.0 if rounding fails, though this should not happen
@@ -347,7 +356,7 @@ func LongitudeFromGridSquare(gridSquare string) (float64, error) {
 // normalizeGridSquare standardizes a provided grid square to the expected case pattern AA99aa.
 // It uppercases the first two letters, keeps digits as-is, and lowercases the last two letters.
 func normalizeGridSquare(s string) string {
-	if len(s) != 6 {
+	if len(s) != 6 || !isASCII(s) {
 		return s
 	}
 	runes := []rune(s)
@@ -361,27 +370,67 @@ func normalizeGridSquare(s string) string {
 	return string(runes)
 }
 
+// normalizeLocator standardizes a 2, 4 or 6 character locator to the expected case pattern (AA, AA99 or AA99aa).
+// Strings of any other length, or containing non-ASCII characters, are returned unchanged for validation to reject.
+func normalizeLocator(s string) string {
+	if !isASCII(s) {
+		return s
+	}
+	switch len(s) {
+	case 2, 4:
+		runes := []rune(s)
+		runes[0] = unicode.ToUpper(runes[0])
+		runes[1] = unicode.ToUpper(runes[1])
+		return string(runes)
+	default:
+		return normalizeGridSquare(s)
+	}
+}
+
+// isASCII reports whether s holds only ASCII characters, so that its bytes and runes line up.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// positionValidators defines the expected character types for each position of a locator
+var positionValidators = []struct {
+	position int
+	validate func(string, int) (bool, error)
+	errMsg   string
+}{
+	{0, isUpperARAtPosition, "first character must be A-R"},
+	{1, isUpperARAtPosition, "second character must be A-R"},
+	{2, isDigitAtPosition, "third character must be a digit"},
+	{3, isDigitAtPosition, "fourth character must be a digit"},
+	{4, isLowerAXAtPosition, "fifth character must be a-x"},
+	{5, isLowerAXAtPosition, "sixth character must be a-x"},
+}
+
 func validateInput(str string) error {
 	if len(str) != 6 {
 		return fmt.Errorf("invalid gridsquare format: %s (must be 6 characters)", str)
 	}
+	return validatePositions(str)
+}
 
-	// Define the expected character types for each position
-	validators := []struct {
-		position int
-		validate func(string, int) (bool, error)
-		errMsg   string
-	}{
-		{0, isUpperARAtPosition, "first character must be A-R"},
-		{1, isUpperARAtPosition, "second character must be A-R"},
-		{2, isDigitAtPosition, "third character must be a digit"},
-		{3, isDigitAtPosition, "fourth character must be a digit"},
-		{4, isLowerAXAtPosition, "fifth character must be a-x"},
-		{5, isLowerAXAtPosition, "sixth character must be a-x"},
+// validateLocator checks that an already normalized locator is a valid field (2 characters),
+// square (4 characters) or subsquare (6 characters).
+func validateLocator(str string) error {
+	if len(str) != 2 && len(str) != 4 && len(str) != 6 {
+		return fmt.Errorf("invalid locator format: %s (must be 2, 4 or 6 characters)", str)
 	}
+	return validatePositions(str)
+}
 
+// validatePositions checks each character of str against its positional validator.
+func validatePositions(str string) error {
 	// Check each position with its corresponding validator
-	for _, v := range validators {
+	for _, v := range positionValidators[:len(str)] {
 		ok, err := v.validate(str, v.position)
 		if err != nil {
 			return err
@@ -0,0 +1,123 @@
+package maidenhead
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const hgtVoid = -32768 // Value marking missing data in SRTM .hgt tiles
+
+// DEM reads ground elevations from a directory of SRTM .hgt tiles, such as N48E011.hgt. Both 3 arc-second
+// (1201×1201) and 1 arc-second (3601×3601) tiles are supported. Tiles are loaded on first use and cached;
+// a DEM is safe for concurrent use.
+type DEM struct {
+	dir   string
+	mu    sync.Mutex
+	tiles map[string]*hgtTile
+}
+
+// hgtTile is one loaded 1°×1° tile. Samples run from north to south, then west to east.
+type hgtTile struct {
+	size    int
+	samples []int16
+}
+
+// OpenDEM returns a DEM reading .hgt tiles from dir.
+func OpenDEM(dir string) (*DEM, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open elevation directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("failed to open elevation directory: %s is not a directory", dir)
+	}
+	return &DEM{dir: dir, tiles: map[string]*hgtTile{}}, nil
+}
+
+// Elevation returns the ground elevation in meters above sea level at (lat, lon), interpolated bilinearly between the
+// surrounding samples. It returns an error if the tile is missing (wrapping fs.ErrNotExist) or the point has no data.
+func (d *DEM) Elevation(lat, lon float64) (float64, error) {
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 || math.IsNaN(lat) || math.IsNaN(lon) {
+		return 0, fmt.Errorf("invalid coordinates: %.5f, %.5f", lat, lon)
+	}
+	lon = normalizeLongitude(lon)
+	tileLat := min(int(math.Floor(lat)), 89) // The north pole falls on the top row of the last tile
+	tileLon := int(math.Floor(lon))
+	tile, err := d.tile(tileLat, tileLon)
+	if err != nil {
+		return 0, err
+	}
+
+	// Fractional row (from the north edge) and column (from the west edge) of the point within the tile
+	n := float64(tile.size - 1)
+	row := (float64(tileLat+1) - lat) * n
+	col := (lon - float64(tileLon)) * n
+	r0, c0 := min(int(row), tile.size-2), min(int(col), tile.size-2)
+	fr, fc := row-float64(r0), col-float64(c0)
+
+	var sum, weight float64
+	for _, s := range []struct {
+		r, c int
+		w    float64
+	}{
+		{r0, c0, (1 - fr) * (1 - fc)},
+		{r0, c0 + 1, (1 - fr) * fc},
+		{r0 + 1, c0, fr * (1 - fc)},
+		{r0 + 1, c0 + 1, fr * fc},
+	} {
+		if v := tile.samples[s.r*tile.size+s.c]; v != hgtVoid {
+			sum += float64(v) * s.w
+			weight += s.w
+		}
+	}
+	if weight == 0 {
+		return 0, fmt.Errorf("no elevation data at %.5f, %.5f", lat, lon)
+	}
+	return sum / weight, nil
+}
+
+// tile returns the cached tile whose south-west corner is (lat, lon), loading it if necessary.
+func (d *DEM) tile(lat, lon int) (*hgtTile, error) {
+	name := hgtTileName(lat, lon)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if t, ok := d.tiles[name]; ok {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(d.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read elevation tile: %w", err)
+	}
+	var size int
+	switch len(data) {
+	case 1201 * 1201 * 2:
+		size = 1201
+	case 3601 * 3601 * 2:
+		size = 3601
+	default:
+		return nil, fmt.Errorf("invalid elevation tile %s: unexpected size %d bytes", name, len(data))
+	}
+	t := &hgtTile{size: size, samples: make([]int16, size*size)}
+	for i := range t.samples {
+		t.samples[i] = int16(binary.BigEndian.Uint16(data[2*i:]))
+	}
+	d.tiles[name] = t
+	return t, nil
+}
+
+// hgtTileName returns the SRTM file name for the tile whose south-west corner is (lat, lon), e.g. N48E011.hgt.
+func hgtTileName(lat, lon int) string {
+	ns, ew := 'N', 'E'
+	if lat < 0 {
+		ns, lat = 'S', -lat
+	}
+	if lon < 0 {
+		ew, lon = 'W', -lon
+	}
+	return fmt.Sprintf("%c%02d%c%03d.hgt", ns, lat, ew, lon)
+}
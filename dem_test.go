@@ -0,0 +1,126 @@
+package maidenhead
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeHGT writes a synthetic 3 arc-second tile to dir whose samples are given by height(row, col), with rows counted
+// from the north edge.
+func writeHGT(t *testing.T, dir, name string, height func(row, col int) int16) {
+	t.Helper()
+	const size = 1201
+	data := make([]byte, size*size*2)
+	for r := range size {
+		for c := range size {
+			binary.BigEndian.PutUint16(data[2*(r*size+c):], uint16(height(r, c)))
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+		t.Fatalf("failed to write tile: %v", err)
+	}
+}
+
+func TestHGTTileName(t *testing.T) {
+	tests := []struct {
+		lat, lon int
+		want     string
+	}{
+		{48, 11, "N48E011.hgt"},
+		{-34, -59, "S34W059.hgt"},
+		{0, 0, "N00E000.hgt"},
+		{-1, -180, "S01W180.hgt"},
+	}
+	for _, tt := range tests {
+		if got := hgtTileName(tt.lat, tt.lon); got != tt.want {
+			t.Errorf("hgtTileName(%d, %d) got %s want %s", tt.lat, tt.lon, got, tt.want)
+		}
+	}
+}
+
+func TestOpenDEM(t *testing.T) {
+	if _, err := OpenDEM(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenDEM(file); err == nil {
+		t.Error("expected error for a file instead of a directory")
+	}
+}
+
+func TestDEMElevation(t *testing.T) {
+	dir := t.TempDir()
+	// Height rises 1 m per column eastwards and 2 m per row southwards
+	writeHGT(t, dir, "N48E011.hgt", func(r, c int) int16 { return int16(c + 2*r) })
+	// A tile that is void except for its north-west corner
+	writeHGT(t, dir, "S34W059.hgt", func(r, c int) int16 {
+		if r == 0 && c == 0 {
+			return 100
+		}
+		return hgtVoid
+	})
+	dem, err := OpenDEM(dir)
+	if err != nil {
+		t.Fatalf("OpenDEM error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     float64
+	}{
+		{"north-west corner", 49 - 1e-9, 11, 0},
+		{"south-west corner", 48, 11, 2400},
+		{"north-east corner", 49 - 1e-9, 12 - 1e-9, 1200},
+		{"between samples", 49 - 0.5/1200, 11 + 0.25/1200, 1.25},
+		{"tile centre", 48.5, 11.5, 1800},
+		{"void neighbours skipped", -33 - 0.5/1200, -59 + 0.5/1200, 100},
+	}
+	for _, tt := range tests {
+		got, err := dem.Elevation(tt.lat, tt.lon)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if !almostEqual(got, tt.want, 1e-3) {
+			t.Errorf("%s: got %.4f want %.4f", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := dem.Elevation(-33.5, -58.5); err == nil {
+		t.Error("expected error for a point with only void samples")
+	}
+	if _, err := dem.Elevation(47.5, 11.5); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist for a missing tile, got %v", err)
+	}
+	if _, err := dem.Elevation(91, 0); err == nil {
+		t.Error("expected error for invalid latitude")
+	}
+	for _, c := range [][2]float64{{math.NaN(), 0}, {0, math.NaN()}} {
+		if _, err := dem.Elevation(c[0], c[1]); err == nil || errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected invalid coordinate error for %v, got %v", c, err)
+		}
+	}
+}
+
+func TestDEMInvalidTile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "N00E000.hgt"), make([]byte, 100), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dem, err := OpenDEM(dir)
+	if err != nil {
+		t.Fatalf("OpenDEM error: %v", err)
+	}
+	if _, err := dem.Elevation(0.5, 0.5); err == nil {
+		t.Error("expected error for a tile of the wrong size")
+	}
+}
@@ -0,0 +1,104 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+)
+
+// LocatorElevation summarizes the ground elevation of a square or subsquare. Heights are in meters above sea level.
+type LocatorElevation struct {
+	GridSquare string  `json:"gridSquare"`
+	CenterM    float64 `json:"center_m"`
+	MinM       float64 `json:"min_m"`
+	MaxM       float64 `json:"max_m"`
+	MeanM      float64 `json:"mean_m"`
+	// Highest and Lowest are the positions of the highest and lowest samples in the cell.
+	Highest Coordinate `json:"highest"`
+	Lowest  Coordinate `json:"lowest"`
+	Samples int        `json:"samples"` // Number of elevation samples inside the cell, excluding voids
+}
+
+// GetLocatorElevation returns the ground elevation at a locator's centre and the minimum, maximum and mean over every
+// elevation sample inside the cell, with the positions of the highest and lowest ground. Only squares and subsquares
+// are accepted, since a field spans 200 tiles. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - gridSquare: The Maidenhead locator (4 or 6 characters)
+//
+// Returns:
+//   - *LocatorElevation: The centre elevation and the statistics over the cell
+//   - error: An error if the locator is invalid or not a square or subsquare, or a tile covering it is missing
+func (d *DEM) GetLocatorElevation(gridSquare string) (*LocatorElevation, error) {
+	c, err := parseLocator(gridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grid square: %w", err)
+	}
+	if c.prec == PrecisionField {
+		return nil, fmt.Errorf("invalid grid square: %s (must be a square or subsquare)", gridSquare)
+	}
+
+	lat, lon := c.center()
+	center, err := d.Elevation(lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read elevation at the centre: %w", err)
+	}
+	result := &LocatorElevation{
+		GridSquare: c.locator(),
+		CenterM:    math.Round(center*10) / 10,
+		MinM:       math.Inf(1),
+		MaxM:       math.Inf(-1),
+	}
+
+	b := c.bounds()
+	var sum float64
+	for tileLat := int(math.Floor(b.MinLat)); float64(tileLat) < b.MaxLat; tileLat++ {
+		for tileLon := int(math.Floor(b.MinLon)); float64(tileLon) < b.MaxLon; tileLon++ {
+			tile, err := d.tile(tileLat, tileLon)
+			if err != nil {
+				return nil, err
+			}
+			n := float64(tile.size - 1)
+
+			// Rows count from the north edge. Samples on an edge shared with the next tile south or east are only
+			// taken from that tile, unless it lies outside the cell.
+			lastRow, lastCol := tile.size-2, tile.size-2
+			if float64(tileLat) <= b.MinLat {
+				lastRow++
+			}
+			if float64(tileLon+1) >= b.MaxLon {
+				lastCol++
+			}
+			r0 := max(int(math.Ceil((float64(tileLat+1)-b.MaxLat)*n-1e-9)), 0)
+			r1 := min(int(math.Floor((float64(tileLat+1)-b.MinLat)*n+1e-9)), lastRow)
+			c0 := max(int(math.Ceil((b.MinLon-float64(tileLon))*n-1e-9)), 0)
+			c1 := min(int(math.Floor((b.MaxLon-float64(tileLon))*n+1e-9)), lastCol)
+
+			for r := r0; r <= r1; r++ {
+				for col := c0; col <= c1; col++ {
+					v := tile.samples[r*tile.size+col]
+					if v == hgtVoid {
+						continue
+					}
+					h := float64(v)
+					pos := Coordinate{
+						Latitude:  math.Round((float64(tileLat+1)-float64(r)/n)*rounding) / rounding,
+						Longitude: math.Round((float64(tileLon)+float64(col)/n)*rounding) / rounding,
+					}
+					if h > result.MaxM {
+						result.MaxM, result.Highest = h, pos
+					}
+					if h < result.MinM {
+						result.MinM, result.Lowest = h, pos
+					}
+					sum += h
+					result.Samples++
+				}
+			}
+		}
+	}
+	if result.Samples == 0 {
+		return nil, fmt.Errorf("no elevation data in %s", result.GridSquare)
+	}
+	result.MeanM = math.Round(sum/float64(result.Samples)*10) / 10
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package maidenhead
+
+import "testing"
+
+func TestGetLocatorElevation(t *testing.T) {
+	dir := t.TempDir()
+	// Height rises 1 m per column eastwards and 2 m per row southwards
+	writeHGT(t, dir, "N48E011.hgt", func(r, c int) int16 { return int16(c + 2*r) })
+	dem, err := OpenDEM(dir)
+	if err != nil {
+		t.Fatalf("OpenDEM error: %v", err)
+	}
+
+	// JN58td spans rows 1000-1050 and columns 700-800 of the tile
+	got, err := dem.GetLocatorElevation("jn58TD")
+	if err != nil {
+		t.Fatalf("GetLocatorElevation error: %v", err)
+	}
+	want := LocatorElevation{
+		GridSquare: "JN58td",
+		CenterM:    2800,
+		MinM:       2700,
+		MaxM:       2900,
+		MeanM:      2800,
+		Highest:    Coordinate{Latitude: 48.125, Longitude: 11.66667},
+		Lowest:     Coordinate{Latitude: 48.16667, Longitude: 11.58333},
+		Samples:    51 * 101,
+	}
+	if *got != want {
+		t.Errorf("got %+v want %+v", *got, want)
+	}
+}
+
+func TestGetLocatorElevationAcrossTiles(t *testing.T) {
+	dir := t.TempDir()
+	// JN58 covers N48E010 and N48E011; a void and a single peak sit in the eastern tile
+	writeHGT(t, dir, "N48E010.hgt", func(_, _ int) int16 { return 300 })
+	writeHGT(t, dir, "N48E011.hgt", func(r, c int) int16 {
+		switch {
+		case r == 600 && c == 600:
+			return 1500
+		case r == 10 && c == 10:
+			return hgtVoid
+		}
+		return 300
+	})
+	dem, err := OpenDEM(dir)
+	if err != nil {
+		t.Fatalf("OpenDEM error: %v", err)
+	}
+
+	got, err := dem.GetLocatorElevation("JN58")
+	if err != nil {
+		t.Fatalf("GetLocatorElevation error: %v", err)
+	}
+	// The column shared by the two tiles is counted once, and the void is skipped
+	if want := 1201*2401 - 1; got.Samples != want {
+		t.Errorf("samples got %d want %d", got.Samples, want)
+	}
+	if got.MinM != 300 || got.MaxM != 1500 || got.Highest != (Coordinate{Latitude: 48.5, Longitude: 11.5}) {
+		t.Errorf("unexpected statistics: %+v", got)
+	}
+	if got.CenterM != 300 || !almostEqual(got.MeanM, 300, 0.1) {
+		t.Errorf("unexpected centre or mean: %+v", got)
+	}
+}
+
+func TestGetLocatorElevationErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeHGT(t, dir, "N48E011.hgt", func(_, _ int) int16 { return 500 })
+	dem, err := OpenDEM(dir)
+	if err != nil {
+		t.Fatalf("OpenDEM error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		gridSquare string
+	}{
+		{"invalid locator", "ZZ99"},
+		{"field", "JN"},
+		{"tile missing at the centre", "JN47"},
+		{"one of two tiles missing", "JN58"},
+	}
+	for _, tt := range tests {
+		if _, err := dem.GetLocatorElevation(tt.gridSquare); err == nil {
+			t.Errorf("%s: expected error for %s", tt.name, tt.gridSquare)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package maidenhead
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EncodeLocator converts a locator into a dense integer index at its own precision.
+// Indexes run from 0 to Precision.Count()-1 and sort in the same order as the canonical locator strings,
+// so they can be stored and compared in place of the strings. Input is case-insensitive.
+//
+// Parameters:
+//   - locator: A 2, 4 or 6 character Maidenhead locator
+//
+// Returns:
+//   - uint32: The dense index of the locator
+//   - Precision: The precision of the locator, needed to decode the index
+//   - error: An error if the locator is invalid
+func EncodeLocator(locator string) (uint32, Precision, error) {
+	c, err := parseLocator(locator)
+	if err != nil {
+		return 0, 0, err
+	}
+	return c.index(), c.prec, nil
+}
+
+// DecodeLocator converts a dense index produced by EncodeLocator back into its canonical locator string.
+func DecodeLocator(index uint32, precision Precision) (string, error) {
+	if !precision.Valid() {
+		return "", fmt.Errorf("unsupported precision: %s", precision)
+	}
+	if int(index) >= precision.Count() {
+		return "", fmt.Errorf("index %d out of range for precision %s", index, precision)
+	}
+	return cellFromIndex(index, precision).locator(), nil
+}
+
+// MortonIndex converts a locator into a Z-order (Morton) index at its own precision by interleaving the bits of
+// its column and row. Neighbouring cells tend to have nearby indexes, which gives better locality than
+// EncodeLocator when indexing by area. Input is case-insensitive.
+func MortonIndex(locator string) (uint32, Precision, error) {
+	c, err := parseLocator(locator)
+	if err != nil {
+		return 0, 0, err
+	}
+	return interleaveBits(uint32(c.col)) | interleaveBits(uint32(c.row))<<1, c.prec, nil
+}
+
+// DecodeMortonIndex converts a Z-order index produced by MortonIndex back into its canonical locator string.
+func DecodeMortonIndex(index uint32, precision Precision) (string, error) {
+	if !precision.Valid() {
+		return "", fmt.Errorf("unsupported precision: %s", precision)
+	}
+	col := int(deinterleaveBits(index))
+	row := int(deinterleaveBits(index >> 1))
+	if col >= precision.divisions() || row >= precision.divisions() {
+		return "", fmt.Errorf("morton index %d out of range for precision %s", index, precision)
+	}
+	return cell{col: col, row: row, prec: precision}.locator(), nil
+}
+
+// CompareLocators orders two locators case-insensitively by their canonical form. Locators of the same precision
+// compare in the same order as their EncodeLocator indexes, and a field or square sorts immediately before
+// the squares or subsquares it contains. It is suitable for use with slices.SortFunc.
+func CompareLocators(a, b string) int {
+	return strings.Compare(normalizeLocator(a), normalizeLocator(b))
+}
+
+// index returns the dense lexical index of the cell: the locator characters taken as a mixed-radix number.
+func (c cell) index() uint32 {
+	perField := c.prec.divisions() / PrecisionField.divisions()
+	idx := uint32(c.col/perField)*18 + uint32(c.row/perField)
+	if c.prec >= PrecisionSquare {
+		perSquare := c.prec.divisions() / PrecisionSquare.divisions()
+		idx = idx*100 + uint32((c.col/perSquare)%10)*10 + uint32((c.row/perSquare)%10)
+	}
+	if c.prec >= PrecisionSubsquare {
+		idx = idx*576 + uint32(c.col%24)*24 + uint32(c.row%24)
+	}
+	return idx
+}
+
+// cellFromIndex is the inverse of cell.index.
+func cellFromIndex(idx uint32, precision Precision) cell {
+	var c cell
+	c.prec = precision
+	if precision >= PrecisionSubsquare {
+		c.col, c.row = int(idx%576/24), int(idx%24)
+		idx /= 576
+	}
+	scale := precision.divisions() / PrecisionSquare.divisions()
+	if precision >= PrecisionSquare {
+		c.col += int(idx%100/10) * scale
+		c.row += int(idx%10) * scale
+		idx /= 100
+	}
+	scale = precision.divisions() / PrecisionField.divisions()
+	c.col += int(idx/18) * scale
+	c.row += int(idx%18) * scale
+	return c
+}
+
+// interleaveBits spreads the low 16 bits of v so that they occupy the even bit positions.
+func interleaveBits(v uint32) uint32 {
+	v &= 0x0000ffff
+	v = (v | v<<8) & 0x00ff00ff
+	v = (v | v<<4) & 0x0f0f0f0f
+	v = (v | v<<2) & 0x33333333
+	v = (v | v<<1) & 0x55555555
+	return v
+}
+
+// deinterleaveBits is the inverse of interleaveBits, collecting the even bit positions of v.
+func deinterleaveBits(v uint32) uint32 {
+	v &= 0x55555555
+	v = (v | v>>1) & 0x33333333
+	v = (v | v>>2) & 0x0f0f0f0f
+	v = (v | v>>4) & 0x00ff00ff
+	v = (v | v>>8) & 0x0000ffff
+	return v
+}
@@ -0,0 +1,131 @@
+package maidenhead
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEncodeLocator_MatchesIterationOrder(t *testing.T) {
+	// Index must equal position in lexical order, for fields and squares
+	for _, p := range []Precision{PrecisionField, PrecisionSquare} {
+		i := uint32(0)
+		seq, _ := LocatorsWithin(p, BoundingBox{MinLat: -90, MinLon: -180, MaxLat: 90, MaxLon: 180})
+		for loc := range seq {
+			idx, prec, err := EncodeLocator(loc)
+			if err != nil {
+				t.Fatalf("EncodeLocator(%q) error: %v", loc, err)
+			}
+			if idx != i || prec != p {
+				t.Fatalf("EncodeLocator(%q) got (%d,%s) want (%d,%s)", loc, idx, prec, i, p)
+			}
+			back, err := DecodeLocator(idx, p)
+			if err != nil || back != loc {
+				t.Fatalf("DecodeLocator(%d,%s) got %q,%v want %q", idx, p, back, err, loc)
+			}
+			i++
+		}
+		if int(i) != p.Count() {
+			t.Errorf("%s count got %d want %d", p, i, p.Count())
+		}
+	}
+}
+
+func TestEncodeLocator_Subsquares(t *testing.T) {
+	idx, prec, err := EncodeLocator("aa00AA")
+	if err != nil || idx != 0 || prec != PrecisionSubsquare {
+		t.Errorf("EncodeLocator(aa00AA) got (%d,%s,%v) want (0,subsquare,nil)", idx, prec, err)
+	}
+	idx, _, _ = EncodeLocator("RR99xx")
+	if int(idx) != PrecisionSubsquare.Count()-1 {
+		t.Errorf("EncodeLocator(RR99xx) got %d want %d", idx, PrecisionSubsquare.Count()-1)
+	}
+
+	var prev uint32
+	first := true
+	seq, _ := LocatorsWithin(PrecisionSubsquare, BoundingBox{MinLat: 47.9, MinLon: 11.9, MaxLat: 48.1, MaxLon: 12.1})
+	for loc := range seq {
+		idx, _, err := EncodeLocator(loc)
+		if err != nil {
+			t.Fatalf("EncodeLocator(%q) error: %v", loc, err)
+		}
+		if !first && idx <= prev {
+			t.Fatalf("index for %q (%d) not greater than previous (%d)", loc, idx, prev)
+		}
+		back, _ := DecodeLocator(idx, PrecisionSubsquare)
+		if back != loc {
+			t.Fatalf("round trip %q -> %d -> %q", loc, idx, back)
+		}
+		prev, first = idx, false
+	}
+}
+
+func TestDecodeLocator_Errors(t *testing.T) {
+	if _, err := DecodeLocator(0, Precision(5)); err == nil {
+		t.Errorf("expected error for unsupported precision")
+	}
+	if _, err := DecodeLocator(324, PrecisionField); err == nil {
+		t.Errorf("expected error for out of range index")
+	}
+	if _, _, err := EncodeLocator("JN5"); err == nil {
+		t.Errorf("expected error for odd length locator")
+	}
+	if _, _, err := EncodeLocator("SN"); err == nil {
+		t.Errorf("expected error for invalid field")
+	}
+}
+
+func TestLocatorAPIs_MultibyteInput(t *testing.T) {
+	// Multibyte characters make the byte length differ from the rune count; they must be rejected, not panic
+	inputs := []string{"é", "éé", "ééé", "JNé", "JN58tđ", "ĴN58td"}
+	for _, in := range inputs {
+		if _, _, err := EncodeLocator(in); err == nil {
+			t.Errorf("EncodeLocator(%q): expected error", in)
+		}
+		if _, _, err := MortonIndex(in); err == nil {
+			t.Errorf("MortonIndex(%q): expected error", in)
+		}
+		if _, err := GetShortPathBearing("JN58td", in); err == nil {
+			t.Errorf("GetShortPathBearing(%q): expected error", in)
+		}
+	}
+}
+
+func TestMortonIndex_RoundTripAndLocality(t *testing.T) {
+	for _, loc := range []string{"AA", "RR", "JN58", "AA00", "RR99", "JN58td", "RR99xx", "AA00aa"} {
+		m, p, err := MortonIndex(loc)
+		if err != nil {
+			t.Fatalf("MortonIndex(%q) error: %v", loc, err)
+		}
+		back, err := DecodeMortonIndex(m, p)
+		if err != nil || back != loc {
+			t.Errorf("Morton round trip %q -> %d -> %q (%v)", loc, m, back, err)
+		}
+	}
+
+	// The four squares of an aligned 2x2 block share consecutive Morton indexes
+	var got []uint32
+	for _, loc := range []string{"JN00", "JN01", "JN10", "JN11"} {
+		m, _, _ := MortonIndex(loc)
+		got = append(got, m)
+	}
+	slices.Sort(got)
+	if got[3]-got[0] != 3 {
+		t.Errorf("expected consecutive Morton indexes for 2x2 block, got %v", got)
+	}
+
+	if _, err := DecodeMortonIndex(0xffffffff, PrecisionField); err == nil {
+		t.Errorf("expected error for out of range Morton index")
+	}
+}
+
+func TestCompareLocators(t *testing.T) {
+	in := []string{"jn58td", "JN", "io91", "JN58", "JN58TA", "AA00aa"}
+	slices.SortFunc(in, CompareLocators)
+	want := []string{"AA00aa", "io91", "JN", "JN58", "JN58TA", "jn58td"}
+	if !slices.Equal(in, want) {
+		t.Errorf("sorted got %v want %v", in, want)
+	}
+	if CompareLocators("JN58TD", "jn58td") != 0 {
+		t.Errorf("expected case-insensitive equality")
+	}
+}
@@ -0,0 +1,129 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxSubsquareFootprintKm limits the range of subsquare footprints; the cells to test grow with the square of the
+// range, reaching up to about 200,000 at this limit and 18 million across the whole globe.
+const maxSubsquareFootprintKm = 1000.0
+
+// Station is a station identified by its callsign and locator.
+type Station struct {
+	Callsign   string `json:"callsign"`
+	GridSquare string `json:"gridSquare"`
+}
+
+// FootprintStation is a station inside a beam footprint.
+type FootprintStation struct {
+	Station
+	Bearing    float64 `json:"bearing"`
+	DistanceKm float64 `json:"distance_km"`
+	OffAxis    float64 `json:"off_axis"` // Angle from the heading, -180 to 180 degrees with positive values clockwise
+}
+
+// BeamFootprint lists the locators and stations inside an antenna's beam sector.
+type BeamFootprint struct {
+	LocalGridSquare string    `json:"localGridSquare"`
+	Heading         float64   `json:"heading"`
+	Beamwidth       float64   `json:"beamwidth"`
+	MaxRangeKm      float64   `json:"max_range_km"`
+	Precision       Precision `json:"precision"`
+	// Locators are the cells whose centres lie inside the sector, in lexical order.
+	Locators []string           `json:"locators"`
+	Stations []FootprintStation `json:"stations"`
+}
+
+// GetBeamFootprint finds every locator at the given precision and every supplied station inside the sector covered by
+// an antenna: within half the beamwidth of the heading and no further than maxRangeKm along the short path. Cells are
+// included when their centre is inside the sector; stations use GetShortPathBearing and GetShortPathDistance. Points
+// at the local station itself are always inside. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead Grid Square of the local station (6 characters)
+//   - heading: The direction the antenna is pointing in degrees from true north
+//   - beamwidth: The width of the sector in degrees (0-360)
+//   - maxRangeKm: The radius of the sector in kilometers (up to half the Earth's circumference, or 1000 at
+//     PrecisionSubsquare)
+//   - precision: The precision of the locators to list (PrecisionField, PrecisionSquare or PrecisionSubsquare)
+//   - stations: The stations to test, such as current spots; may be nil
+//
+// Returns:
+//   - *BeamFootprint: The locators and stations inside the sector, stations in input order
+//   - error: An error if any grid square, the heading or any other parameter is invalid
+func GetBeamFootprint(localGridSquare string, heading, beamwidth, maxRangeKm float64, precision Precision, stations []Station) (*BeamFootprint, error) {
+	if math.IsNaN(heading) || math.IsInf(heading, 0) {
+		return nil, fmt.Errorf("invalid heading: %v", heading)
+	}
+	if beamwidth <= 0 || beamwidth > 360 || math.IsNaN(beamwidth) {
+		return nil, fmt.Errorf("invalid beamwidth: %v (must be 0-360)", beamwidth)
+	}
+	if maxRangeKm <= 0 || maxRangeKm > math.Pi*earthRad || math.IsNaN(maxRangeKm) {
+		return nil, fmt.Errorf("invalid range: %v km (must be 0-%.0f)", maxRangeKm, math.Pi*earthRad)
+	}
+	if !precision.Valid() {
+		return nil, fmt.Errorf("unsupported precision: %s", precision)
+	}
+	if precision == PrecisionSubsquare && maxRangeKm > maxSubsquareFootprintKm {
+		return nil, fmt.Errorf("invalid range: %v km (must be at most %.0f at %s)", maxRangeKm, maxSubsquareFootprintKm, precision)
+	}
+	local, err := extractCoordinates(localGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local grid square: %w", err)
+	}
+
+	footprint := &BeamFootprint{
+		LocalGridSquare: localGridSquare,
+		Heading:         heading,
+		Beamwidth:       beamwidth,
+		MaxRangeKm:      maxRangeKm,
+		Precision:       precision,
+	}
+	inBeam := func(bearing, distanceKm float64) bool {
+		return distanceKm == 0 || (distanceKm <= maxRangeKm && math.Abs(offAxisAngle(bearing, heading)) <= beamwidth/2)
+	}
+
+	box := rangeBoundingBox(local.Latitude, local.Longitude, maxRangeKm)
+	walkCells(PrecisionField, 0, 0, precision, &box, func(c cell) bool {
+		lat, lon := c.center()
+		d := haversineKm(local.Latitude, local.Longitude, lat, lon)
+		if inBeam(CalculateBearing(local.Latitude, local.Longitude, lat, lon), d) {
+			footprint.Locators = append(footprint.Locators, c.locator())
+		}
+		return true
+	})
+
+	for i, s := range stations {
+		bearing, err := GetShortPathBearing(localGridSquare, s.GridSquare)
+		if err != nil {
+			return nil, fmt.Errorf("station %d: %w", i+1, err)
+		}
+		distanceKm, _, err := GetShortPathDistance(localGridSquare, s.GridSquare)
+		if err != nil {
+			return nil, fmt.Errorf("station %d: %w", i+1, err)
+		}
+		if inBeam(bearing, distanceKm) {
+			footprint.Stations = append(footprint.Stations, FootprintStation{
+				Station:    s,
+				Bearing:    bearing,
+				DistanceKm: distanceKm,
+				OffAxis:    math.Round(offAxisAngle(bearing, heading)*10) / 10,
+			})
+		}
+	}
+	return footprint, nil
+}
+
+// rangeBoundingBox returns a box containing every point within rangeKm of (lat, lon). The box spans all longitudes
+// when the circle reaches a pole.
+func rangeBoundingBox(lat, lon, rangeKm float64) BoundingBox {
+	angular := toDegrees(rangeKm / earthRad)
+	box := BoundingBox{MinLat: max(lat-angular, -90), MinLon: -180, MaxLat: min(lat+angular, 90), MaxLon: 180}
+	if box.MinLat == -90 || box.MaxLat == 90 {
+		return box
+	}
+	dLon := toDegrees(math.Asin(math.Sin(rangeKm/earthRad) / math.Cos(toRadians(lat))))
+	box.MinLon, box.MaxLon = normalizeLongitude(lon-dLon), normalizeLongitude(lon+dLon)
+	return box
+}
@@ -0,0 +1,96 @@
+package maidenhead
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestGetBeamFootprintLocators(t *testing.T) {
+	tests := []struct {
+		name               string
+		local              string
+		heading, beamwidth float64
+		rangeKm            float64
+	}{
+		{"narrow beam north", "JN58td", 0, 30, 800},
+		{"wide beam across the antimeridian", "RB99xm", 90, 120, 1500},
+		{"range reaching the pole", "JQ79ll", 200, 60, 2500},
+		{"full circle", "FN31pr", 123, 360, 300},
+	}
+	for _, tt := range tests {
+		got, err := GetBeamFootprint(tt.local, tt.heading, tt.beamwidth, tt.rangeKm, PrecisionSquare, nil)
+		if err != nil {
+			t.Fatalf("%s: GetBeamFootprint error: %v", tt.name, err)
+		}
+
+		// Compare with a brute-force scan of every square
+		lat0, lon0, _ := locatorCenter(tt.local)
+		var want []string
+		for sq := range Squares() {
+			lat, lon, _ := locatorCenter(sq)
+			d := haversineKm(lat0, lon0, lat, lon)
+			off := math.Abs(offAxisAngle(CalculateBearing(lat0, lon0, lat, lon), tt.heading))
+			if d <= tt.rangeKm && off <= tt.beamwidth/2 {
+				want = append(want, sq)
+			}
+		}
+		if len(want) == 0 || !slices.Equal(got.Locators, want) {
+			t.Errorf("%s: got %d locators want %d", tt.name, len(got.Locators), len(want))
+		}
+	}
+}
+
+func TestGetBeamFootprintStations(t *testing.T) {
+	stations := []Station{
+		{"DL1ABC", "JO62qm"}, // 13.7°, about 500 km
+		{"HA5XYZ", "KN04fr"}, // 116°
+		{"SM0AAA", "KP20le"}, // 27.7°, about 1500 km
+		{"DL2DEF", "jn58td"}, // The local station's own subsquare
+	}
+	got, err := GetBeamFootprint("JN58td", 10, 40, 1000, PrecisionField, stations)
+	if err != nil {
+		t.Fatalf("GetBeamFootprint error: %v", err)
+	}
+	if len(got.Stations) != 2 || got.Stations[0].Callsign != "DL1ABC" || got.Stations[1].Callsign != "DL2DEF" {
+		t.Fatalf("unexpected stations: %+v", got.Stations)
+	}
+	s := got.Stations[0]
+	bearing, _ := GetShortPathBearing("JN58td", "JO62qm")
+	distance, _, _ := GetShortPathDistance("JN58td", "JO62qm")
+	if s.Bearing != bearing || s.DistanceKm != distance || !almostEqual(s.OffAxis, bearing-10, 0.05) {
+		t.Errorf("unexpected station details: %+v", s)
+	}
+	if !slices.Contains(got.Locators, "JO") || slices.Contains(got.Locators, "KN") {
+		t.Errorf("unexpected fields: %v", got.Locators)
+	}
+}
+
+func TestGetBeamFootprintErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		local     string
+		beamwidth float64
+		rangeKm   float64
+		precision Precision
+		stations  []Station
+	}{
+		{"invalid local", "JN58", 30, 500, PrecisionSquare, nil},
+		{"zero beamwidth", "JN58td", 0, 500, PrecisionSquare, nil},
+		{"zero range", "JN58td", 30, 0, PrecisionSquare, nil},
+		{"range beyond antipode", "JN58td", 30, 25000, PrecisionSquare, nil},
+		{"subsquare range too long", "JN58td", 30, 5000, PrecisionSubsquare, nil},
+		{"invalid precision", "JN58td", 30, 500, Precision(3), nil},
+		{"invalid station", "JN58td", 30, 500, PrecisionSquare, []Station{{"X", "ZZ99zz"}}},
+	}
+	for _, tt := range tests {
+		if _, err := GetBeamFootprint(tt.local, 0, tt.beamwidth, tt.rangeKm, tt.precision, tt.stations); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	for _, heading := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := GetBeamFootprint("JN58td", heading, 30, 500, PrecisionSquare, nil); err == nil {
+			t.Errorf("heading %v: expected error", heading)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const auroraSamples = 100 // Number of segments a path is split into when checking for the auroral oval
+
+// AuroralPathRisk describes how a great-circle path relates to the auroral oval.
+type AuroralPathRisk struct {
+	PathType PathType `json:"path_type"`
+	Bearing  float64  `json:"bearing"`
+	// MaxGeomagneticLatitude is the highest absolute geomagnetic latitude reached along the path.
+	MaxGeomagneticLatitude float64 `json:"max_geomagnetic_latitude"`
+	// OvalFraction is the fraction of the sampled points that lie inside the auroral oval.
+	OvalFraction    float64 `json:"oval_fraction"`
+	CrossesOval     bool    `json:"crosses_oval"`
+	CrossesPolarCap bool    `json:"crosses_polar_cap"`
+}
+
+// AuroralRisk reports whether the short and long paths between two locators pass through the auroral oval
+// for a given Kp index.
+type AuroralRisk struct {
+	LocalGridSquare  string  `json:"localGridSquare"`
+	RemoteGridSquare string  `json:"remoteGridSquare"`
+	Kp               float64 `json:"kp"`
+	// OvalEquatorward and OvalPoleward are the absolute geomagnetic latitudes bounding the oval.
+	OvalEquatorward float64         `json:"oval_equatorward"`
+	OvalPoleward    float64         `json:"oval_poleward"`
+	ShortPath       AuroralPathRisk `json:"short_path"`
+	LongPath        AuroralPathRisk `json:"long_path"`
+}
+
+// GetGeomagneticCoordinates converts the centre of a locator into centred dipole geomagnetic coordinates for a given
+// date, using the dipole terms of the embedded World Magnetic Model. Latitude and longitude are in degrees, rounded to
+// 5 decimal places; geomagnetic longitude is zero on the meridian through the geographic south pole.
+// Grid square input is case-insensitive.
+//
+// Parameters:
+//   - gridSquare: The Maidenhead locator (2, 4 or 6 characters)
+//   - date: The date of interest, which must fall within the model's validity period (2025.0 to 2030.0)
+//
+// Returns:
+//   - *Coordinate: The geomagnetic latitude and longitude
+//   - error: An error if the locator is invalid or the date is outside the model's validity period
+func GetGeomagneticCoordinates(gridSquare string, date time.Time) (*Coordinate, error) {
+	lat, lon, err := locatorCenter(gridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grid square: %w", err)
+	}
+	poleLat, poleLon, err := geomagneticPole(date)
+	if err != nil {
+		return nil, err
+	}
+	mlat, mlon := dipoleCoordinates(lat, lon, poleLat, poleLon)
+	return &Coordinate{
+		Latitude:  math.Round(mlat*rounding) / rounding,
+		Longitude: math.Round(mlon*rounding) / rounding,
+	}, nil
+}
+
+// GetAuroralRisk checks whether the short and long great-circle paths between two locators pass through the auroral
+// oval for a given planetary Kp index. The oval is modelled as a band of geomagnetic latitude in each hemisphere whose
+// equatorward edge moves from 66° at Kp 0 to 48° at Kp 9; this ignores its day/night asymmetry, so treat the result
+// as a flag rather than a forecast. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead locator of the local station (2, 4 or 6 characters)
+//   - remoteGridSquare: The Maidenhead locator of the remote station (2, 4 or 6 characters)
+//   - kp: The planetary K index (0-9)
+//   - date: The date of interest, which must fall within the model's validity period (2025.0 to 2030.0)
+//
+// Returns:
+//   - *AuroralRisk: The oval boundaries and the risk for each path
+//   - error: An error if either locator, the Kp index or the date is invalid
+func GetAuroralRisk(localGridSquare, remoteGridSquare string, kp float64, date time.Time) (*AuroralRisk, error) {
+	if kp < 0 || kp > 9 || math.IsNaN(kp) {
+		return nil, fmt.Errorf("invalid Kp index: %v (must be 0-9)", kp)
+	}
+	poleLat, poleLon, err := geomagneticPole(date)
+	if err != nil {
+		return nil, err
+	}
+
+	equatorward, poleward := auroralOvalBounds(kp)
+	risk := &AuroralRisk{
+		LocalGridSquare:  localGridSquare,
+		RemoteGridSquare: remoteGridSquare,
+		Kp:               kp,
+		OvalEquatorward:  equatorward,
+		OvalPoleward:     poleward,
+	}
+	for _, pathType := range []PathType{ShortPath, LongPath} {
+		path, err := newGreatCirclePath(localGridSquare, remoteGridSquare, pathType)
+		if err != nil {
+			return nil, err
+		}
+
+		pathRisk := AuroralPathRisk{PathType: pathType, Bearing: math.Round(path.bearing*10) / 10}
+		inOval := 0
+		points := path.samples(auroraSamples)
+		for _, pt := range points {
+			mlat, _ := dipoleCoordinates(pt[0], pt[1], poleLat, poleLon)
+			mlat = math.Abs(mlat)
+			pathRisk.MaxGeomagneticLatitude = math.Max(pathRisk.MaxGeomagneticLatitude, mlat)
+			switch {
+			case mlat > poleward:
+				pathRisk.CrossesPolarCap = true
+			case mlat >= equatorward:
+				inOval++
+			}
+		}
+		pathRisk.MaxGeomagneticLatitude = math.Round(pathRisk.MaxGeomagneticLatitude*10) / 10
+		pathRisk.OvalFraction = float64(inOval) / float64(len(points))
+		pathRisk.CrossesOval = inOval > 0 || pathRisk.CrossesPolarCap
+
+		if pathType == ShortPath {
+			risk.ShortPath = pathRisk
+		} else {
+			risk.LongPath = pathRisk
+		}
+	}
+	return risk, nil
+}
+
+// auroralOvalBounds returns the equatorward and poleward geomagnetic latitudes of the auroral oval for a Kp index.
+// Both edges move towards the equator as activity increases, the equatorward edge roughly 2° per Kp step.
+func auroralOvalBounds(kp float64) (float64, float64) {
+	return 66 - 2*kp, 76 - kp
+}
+
+// geomagneticPole returns the geographic latitude and longitude in degrees of the northern geomagnetic (dipole) pole
+// at t, from the degree-1 World Magnetic Model coefficients.
+func geomagneticPole(t time.Time) (float64, float64, error) {
+	year, err := wmmYear(t)
+	if err != nil {
+		return 0, 0, err
+	}
+	var g10, g11, h11 float64
+	dt := year - wmmEpoch
+	for _, c := range wmmCoefficients[:2] {
+		if c.m == 0 {
+			g10 = c.g + c.gDot*dt
+		} else {
+			g11, h11 = c.g+c.gDot*dt, c.h+c.hDot*dt
+		}
+	}
+	b0 := math.Sqrt(g10*g10 + g11*g11 + h11*h11)
+	return toDegrees(math.Asin(-g10 / b0)), toDegrees(math.Atan2(-h11, -g11)), nil
+}
+
+// dipoleCoordinates rotates a geographic latitude and longitude into the dipole frame whose north pole is at
+// (poleLat, poleLon). All values are in degrees.
+func dipoleCoordinates(lat, lon, poleLat, poleLon float64) (float64, float64) {
+	phi, phiP := toRadians(lat), toRadians(poleLat)
+	dLon := toRadians(lon - poleLon)
+	sinMlat := math.Sin(phi)*math.Sin(phiP) + math.Cos(phi)*math.Cos(phiP)*math.Cos(dLon)
+	mlat := math.Asin(math.Max(-1, math.Min(1, sinMlat)))
+	mlon := math.Atan2(
+		math.Cos(phi)*math.Sin(dLon),
+		math.Cos(phi)*math.Sin(phiP)*math.Cos(dLon)-math.Sin(phi)*math.Cos(phiP),
+	)
+	return toDegrees(mlat), normalizeLongitude(toDegrees(mlon))
+}
@@ -0,0 +1,101 @@
+package maidenhead
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGeomagneticPole(t *testing.T) {
+	lat, lon, err := geomagneticPole(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("geomagneticPole error: %v", err)
+	}
+	// The WMM2025 dipole pole lies near 80.8°N 72.8°W
+	if !almostEqual(lat, 80.8, 0.2) || !almostEqual(lon, -72.8, 0.3) {
+		t.Errorf("got %.2f, %.2f want ~80.8, -72.8", lat, lon)
+	}
+	if _, _, err := geomagneticPole(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("expected error for date before the model epoch")
+	}
+}
+
+func TestDipoleCoordinates(t *testing.T) {
+	// The pole itself maps to geomagnetic latitude 90 and the geographic north pole to longitude 180
+	if mlat, _ := dipoleCoordinates(80, -70, 80, -70); !almostEqual(mlat, 90, 1e-9) {
+		t.Errorf("pole got latitude %v want 90", mlat)
+	}
+	if mlat, mlon := dipoleCoordinates(90, 0, 80, -70); !almostEqual(mlat, 80, 1e-9) || !almostEqual(mlon, -180, 1e-9) {
+		t.Errorf("geographic pole got %v, %v want 80, -180", mlat, mlon)
+	}
+	if mlat, _ := dipoleCoordinates(-80, 110, 80, -70); !almostEqual(mlat, -90, 1e-9) {
+		t.Errorf("antipode got latitude %v want -90", mlat)
+	}
+}
+
+func TestGetGeomagneticCoordinates(t *testing.T) {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		grid string
+		want float64
+	}{
+		{"JO65", 55.1}, // Copenhagen
+		{"FN31", 50.7}, // Connecticut
+		{"PM95", 27.6}, // Tokyo
+		{"QF56", -39.9},
+	}
+	for _, tt := range tests {
+		got, err := GetGeomagneticCoordinates(tt.grid, at)
+		if err != nil {
+			t.Fatalf("GetGeomagneticCoordinates(%s) error: %v", tt.grid, err)
+		}
+		if !almostEqual(got.Latitude, tt.want, 0.2) {
+			t.Errorf("%s got latitude %.2f want ~%.1f", tt.grid, got.Latitude, tt.want)
+		}
+	}
+	if _, err := GetGeomagneticCoordinates("BAD", at); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
+
+func TestGetAuroralRisk(t *testing.T) {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Europe to the US west coast goes over the polar cap
+	risk, err := GetAuroralRisk("JO65ha", "CM87wj", 2, at)
+	if err != nil {
+		t.Fatalf("GetAuroralRisk error: %v", err)
+	}
+	if !risk.ShortPath.CrossesOval || !risk.ShortPath.CrossesPolarCap {
+		t.Errorf("expected polar short path to cross the oval and polar cap: %+v", risk.ShortPath)
+	}
+	sp, _ := GetShortPathBearing("JO65ha", "CM87wj")
+	if risk.ShortPath.Bearing != sp || risk.ShortPath.PathType != ShortPath || risk.LongPath.PathType != LongPath {
+		t.Errorf("unexpected path details: %+v", risk)
+	}
+
+	// A mid-latitude path is clear when quiet and affected in a storm
+	quiet, err := GetAuroralRisk("JO65ha", "PM95vq", 2, at)
+	if err != nil {
+		t.Fatalf("GetAuroralRisk error: %v", err)
+	}
+	storm, err := GetAuroralRisk("JO65ha", "PM95vq", 8, at)
+	if err != nil {
+		t.Fatalf("GetAuroralRisk error: %v", err)
+	}
+	if quiet.ShortPath.CrossesOval || quiet.ShortPath.OvalFraction != 0 {
+		t.Errorf("expected quiet path to be clear: %+v", quiet.ShortPath)
+	}
+	if !storm.ShortPath.CrossesOval || storm.ShortPath.OvalFraction <= 0 {
+		t.Errorf("expected storm path to cross the oval: %+v", storm.ShortPath)
+	}
+	if storm.OvalEquatorward >= quiet.OvalEquatorward || storm.OvalPoleward <= storm.OvalEquatorward {
+		t.Errorf("unexpected oval bounds: quiet %+v storm %+v", quiet, storm)
+	}
+
+	if _, err := GetAuroralRisk("JO65ha", "PM95vq", 10, at); err == nil {
+		t.Errorf("expected error for Kp out of range")
+	}
+	if _, err := GetAuroralRisk("JO65ha", "BAD", 3, at); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
@@ -0,0 +1,135 @@
+package maidenhead
+
+import (
+	"fmt"
+	"time"
+)
+
+const greylineStep = time.Minute // Sampling interval used when searching for greyline windows
+
+// GreylineMode selects which lighting condition both stations must share for a window to count.
+type GreylineMode int
+
+const (
+	// GreylineBoth requires both stations to be in the grey line: the Sun between civil twilight (-6°)
+	// and sunrise/sunset (-0.833°), on either the morning or evening side.
+	GreylineBoth GreylineMode = iota
+	// DarknessBoth requires both stations to be in darkness: the Sun below civil twilight (-6°).
+	DarknessBoth
+)
+
+// String returns a human-readable name for the mode.
+func (m GreylineMode) String() string {
+	switch m {
+	case GreylineBoth:
+		return "greyline"
+	case DarknessBoth:
+		return "darkness"
+	default:
+		return fmt.Sprintf("GreylineMode(%d)", int(m))
+	}
+}
+
+// GreylineWindow is a period during which both stations share the requested lighting condition.
+type GreylineWindow struct {
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+}
+
+// inGreyline reports whether a solar elevation lies within the grey line band.
+func inGreyline(elevation float64) bool {
+	return elevation >= civilAltitude && elevation <= sunriseAltitude
+}
+
+// inDarkness reports whether a solar elevation is below the end of civil twilight.
+func inDarkness(elevation float64) bool {
+	return elevation < civilAltitude
+}
+
+// GetGreylineWindows finds the periods when both stations are simultaneously in the grey line (or both in darkness)
+// over a range of UTC dates. Windows are found to the nearest minute; a window that continues past the end of the
+// range is cut off there. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead locator of the local station (2, 4 or 6 characters)
+//   - remoteGridSquare: The Maidenhead locator of the remote station (2, 4 or 6 characters)
+//   - from: The first UTC date to search
+//   - to: The last UTC date to search (inclusive)
+//   - mode: GreylineBoth or DarknessBoth
+//
+// Returns:
+//   - []GreylineWindow: The windows in chronological order, each with its duration
+//   - error: An error if either locator, the date range or the mode is invalid
+func GetGreylineWindows(localGridSquare, remoteGridSquare string, from, to time.Time, mode GreylineMode) ([]GreylineWindow, error) {
+	localLat, localLon, err := locatorCenter(localGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local grid square: %w", err)
+	}
+	remoteLat, remoteLon, err := locatorCenter(remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote grid square: %w", err)
+	}
+
+	var condition func(float64) bool
+	switch mode {
+	case GreylineBoth:
+		condition = inGreyline
+	case DarknessBoth:
+		condition = inDarkness
+	default:
+		return nil, fmt.Errorf("unsupported greyline mode: %s", mode)
+	}
+
+	start := utcDay(from)
+	end := utcDay(to).Add(24 * time.Hour)
+	if !end.After(start) {
+		return nil, fmt.Errorf("invalid date range: %s to %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+
+	both := func(t time.Time) bool {
+		localElev, _ := sunPosition(localLat, localLon, t)
+		if !condition(localElev) {
+			return false
+		}
+		remoteElev, _ := sunPosition(remoteLat, remoteLon, t)
+		return condition(remoteElev)
+	}
+	var windows []GreylineWindow
+	for _, span := range findWindows(start, end, greylineStep, both) {
+		windows = append(windows, GreylineWindow{Start: span.start, End: span.end, Duration: span.end.Sub(span.start)})
+	}
+	return windows, nil
+}
+
+// timeSpan is a half-open interval of time found by findWindows.
+type timeSpan struct {
+	start time.Time
+	end   time.Time
+}
+
+// findWindows samples match from start to end in steps and returns the contiguous periods where it holds.
+func findWindows(start, end time.Time, step time.Duration, match func(time.Time) bool) []timeSpan {
+	var (
+		windows []timeSpan
+		open    bool
+		opened  time.Time
+	)
+	closeWindow := func(at time.Time) {
+		windows = append(windows, timeSpan{start: opened, end: at})
+		open = false
+	}
+
+	for t := start; t.Before(end); t = t.Add(step) {
+		ok := match(t)
+		if ok && !open {
+			open, opened = true, t
+		} else if !ok && open {
+			closeWindow(t)
+		}
+	}
+	if open {
+		closeWindow(end)
+	}
+	return windows
+}
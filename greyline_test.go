@@ -0,0 +1,71 @@
+package maidenhead
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetGreylineWindows_SameStationMatchesTwilight(t *testing.T) {
+	day := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	windows, err := GetGreylineWindows("IO91wm", "IO91wm", day, day, GreylineBoth)
+	if err != nil {
+		t.Fatalf("GetGreylineWindows error: %v", err)
+	}
+	if len(windows) != 2 {
+		t.Fatalf("expected morning and evening windows, got %d: %+v", len(windows), windows)
+	}
+	st, _ := GetSunTimes("IO91wm", day)
+	if !withinMinutes(windows[0].Start, st.Civil.Start, 2) || !withinMinutes(windows[0].End, st.Daylight.Start, 2) {
+		t.Errorf("morning window %s-%s, want ~%s-%s", windows[0].Start, windows[0].End, st.Civil.Start, st.Daylight.Start)
+	}
+	if !withinMinutes(windows[1].Start, st.Daylight.End, 2) || !withinMinutes(windows[1].End, st.Civil.End, 2) {
+		t.Errorf("evening window %s-%s, want ~%s-%s", windows[1].Start, windows[1].End, st.Daylight.End, st.Civil.End)
+	}
+	for _, w := range windows {
+		if w.Duration != w.End.Sub(w.Start) || w.Duration < 20*time.Minute || w.Duration > time.Hour {
+			t.Errorf("unexpected window duration: %+v", w)
+		}
+	}
+}
+
+func TestGetGreylineWindows_DarknessBetweenStations(t *testing.T) {
+	from := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 3, 0, 0, 0, 0, time.UTC)
+	windows, err := GetGreylineWindows("JN58td", "FN31pr", from, to, DarknessBoth)
+	if err != nil {
+		t.Fatalf("GetGreylineWindows error: %v", err)
+	}
+	if len(windows) < 3 {
+		t.Fatalf("expected a darkness window each night, got %d", len(windows))
+	}
+
+	localLat, localLon, _ := locatorCenter("JN58td")
+	remoteLat, remoteLon, _ := locatorCenter("FN31pr")
+	for _, w := range windows {
+		if w.Start.Before(from) || w.End.After(to.Add(24*time.Hour)) {
+			t.Errorf("window outside range: %+v", w)
+		}
+		mid := w.Start.Add(w.Duration / 2)
+		le, _ := sunPosition(localLat, localLon, mid)
+		re, _ := sunPosition(remoteLat, remoteLon, mid)
+		if le >= civilAltitude || re >= civilAltitude {
+			t.Errorf("window %+v midpoint not dark at both ends: local %.1f remote %.1f", w, le, re)
+		}
+	}
+}
+
+func TestGetGreylineWindows_Errors(t *testing.T) {
+	day := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	if _, err := GetGreylineWindows("BAD", "IO91", day, day, GreylineBoth); err == nil {
+		t.Errorf("expected error for bad local locator")
+	}
+	if _, err := GetGreylineWindows("IO91", "BAD", day, day, GreylineBoth); err == nil {
+		t.Errorf("expected error for bad remote locator")
+	}
+	if _, err := GetGreylineWindows("IO91", "FN31", day, day.AddDate(0, 0, -1), GreylineBoth); err == nil {
+		t.Errorf("expected error for reversed date range")
+	}
+	if _, err := GetGreylineWindows("IO91", "FN31", day, day, GreylineMode(9)); err == nil {
+		t.Errorf("expected error for unknown mode")
+	}
+}
@@ -0,0 +1,232 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+)
+
+// Precision identifies how many characters of a Maidenhead locator are significant.
+type Precision int
+
+const (
+	PrecisionField     Precision = 2 // Field, e.g. JN (20° x 10°)
+	PrecisionSquare    Precision = 4 // Square, e.g. JN58 (2° x 1°)
+	PrecisionSubsquare Precision = 6 // Subsquare, e.g. JN58td (5' x 2.5')
+)
+
+// Valid reports whether p is one of the supported precisions.
+func (p Precision) Valid() bool {
+	return p == PrecisionField || p == PrecisionSquare || p == PrecisionSubsquare
+}
+
+// String returns a human-readable name for the precision.
+func (p Precision) String() string {
+	switch p {
+	case PrecisionField:
+		return "field"
+	case PrecisionSquare:
+		return "square"
+	case PrecisionSubsquare:
+		return "subsquare"
+	default:
+		return fmt.Sprintf("Precision(%d)", int(p))
+	}
+}
+
+// Count returns the number of distinct locators at precision p (324, 32,400 or 18,662,400), or 0 if p is not valid.
+func (p Precision) Count() int {
+	return p.divisions() * p.divisions()
+}
+
+// subdivisions returns how many cells each axis of the parent cell is split into at precision p
+// (18 fields, 10 squares per field, 24 subsquares per square).
+func (p Precision) subdivisions() int {
+	switch p {
+	case PrecisionField:
+		return 18
+	case PrecisionSquare:
+		return 10
+	case PrecisionSubsquare:
+		return 24
+	default:
+		return 0
+	}
+}
+
+// divisions returns the total number of cells along each axis of the globe at precision p.
+func (p Precision) divisions() int {
+	switch p {
+	case PrecisionField:
+		return 18
+	case PrecisionSquare:
+		return 180
+	case PrecisionSubsquare:
+		return 4320
+	default:
+		return 0
+	}
+}
+
+// cellWidth returns the width of a cell in degrees of longitude at precision p.
+func (p Precision) cellWidth() float64 {
+	switch p {
+	case PrecisionField:
+		return fieldWidth
+	case PrecisionSquare:
+		return squareWidth
+	default:
+		return subsquareWidth
+	}
+}
+
+// cellHeight returns the height of a cell in degrees of latitude at precision p.
+func (p Precision) cellHeight() float64 {
+	switch p {
+	case PrecisionField:
+		return fieldHeight
+	case PrecisionSquare:
+		return squareHeight
+	default:
+		return subsquareHeight
+	}
+}
+
+// Coordinate is a latitude/longitude position in degrees.
+type Coordinate struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// BoundingBox is a latitude/longitude rectangle in degrees.
+// A box whose MinLon is greater than its MaxLon is taken to cross the antimeridian.
+type BoundingBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLon float64 `json:"max_lon"`
+}
+
+// validate checks that the box lies within the valid latitude/longitude ranges.
+func (b BoundingBox) validate() error {
+	if b.MinLat < -90 || b.MaxLat > 90 || b.MinLat > b.MaxLat {
+		return fmt.Errorf("invalid bounding box latitude range: %.5f to %.5f", b.MinLat, b.MaxLat)
+	}
+	if b.MinLon < -180 || b.MinLon > 180 || b.MaxLon < -180 || b.MaxLon > 180 {
+		return fmt.Errorf("invalid bounding box longitude range: %.5f to %.5f", b.MinLon, b.MaxLon)
+	}
+	return nil
+}
+
+// overlaps reports whether the cell bounds c (which never cross the antimeridian) share any area with b.
+// Cells are treated as half-open on their north and east edges so that a box lying exactly on a cell
+// boundary does not pick up its neighbours, except at the poles and the antimeridian.
+func (b BoundingBox) overlaps(c BoundingBox) bool {
+	if !spanOverlaps(c.MinLat, c.MaxLat, b.MinLat, b.MaxLat, 90) {
+		return false
+	}
+	if b.MinLon <= b.MaxLon {
+		return spanOverlaps(c.MinLon, c.MaxLon, b.MinLon, b.MaxLon, 180)
+	}
+	return spanOverlaps(c.MinLon, c.MaxLon, b.MinLon, 180, 180) ||
+		spanOverlaps(c.MinLon, c.MaxLon, -180, b.MaxLon, 180)
+}
+
+// spanOverlaps reports whether the half-open span [lo, hi) intersects the closed span [minV, maxV].
+// A span ending at limit is treated as closed.
+func spanOverlaps(lo, hi, minV, maxV, limit float64) bool {
+	if minV == maxV {
+		return lo <= minV && (minV < hi || (hi == limit && minV == limit))
+	}
+	return lo < maxV && hi > minV
+}
+
+// cell identifies a grid cell by its column (west to east) and row (south to north) at a given precision.
+type cell struct {
+	col  int
+	row  int
+	prec Precision
+}
+
+// parseLocator normalizes and validates a 2, 4 or 6 character locator and returns the cell it identifies.
+func parseLocator(locator string) (cell, error) {
+	normalized := normalizeLocator(locator)
+	if err := validateLocator(normalized); err != nil {
+		return cell{}, err
+	}
+
+	c := cell{
+		col:  int(normalized[0] - 'A'),
+		row:  int(normalized[1] - 'A'),
+		prec: Precision(len(normalized)),
+	}
+	if c.prec >= PrecisionSquare {
+		c.col = c.col*10 + int(normalized[2]-'0')
+		c.row = c.row*10 + int(normalized[3]-'0')
+	}
+	if c.prec >= PrecisionSubsquare {
+		c.col = c.col*24 + int(normalized[4]-'a')
+		c.row = c.row*24 + int(normalized[5]-'a')
+	}
+	return c, nil
+}
+
+// cellAt returns the cell at precision p containing the point (lat, lon) in degrees. Longitude is wrapped into
+// [-180, 180) and a latitude of exactly 90 falls in the northernmost row.
+func cellAt(lat, lon float64, p Precision) cell {
+	n := p.divisions()
+	col := int(math.Floor((normalizeLongitude(lon) + 180) * float64(n) / 360))
+	row := int(math.Floor((lat + 90) * float64(n) / 180))
+	return cell{col: min(max(col, 0), n-1), row: min(max(row, 0), n-1), prec: p}
+}
+
+// locatorCenter returns the latitude and longitude of the centre of a 2, 4 or 6 character locator.
+// For 6-character locators this matches LatitudeFromGridSquare and LongitudeFromGridSquare.
+func locatorCenter(locator string) (float64, float64, error) {
+	c, err := parseLocator(locator)
+	if err != nil {
+		return 0, 0, err
+	}
+	lat, lon := c.center()
+	return lat, lon, nil
+}
+
+// locator returns the canonical (AA99aa) locator string for the cell.
+func (c cell) locator() string {
+	buf := make([]byte, 0, int(c.prec))
+	perField := c.prec.divisions() / PrecisionField.divisions()
+	buf = append(buf, byte('A'+c.col/perField), byte('A'+c.row/perField))
+	if c.prec >= PrecisionSquare {
+		perSquare := c.prec.divisions() / PrecisionSquare.divisions()
+		buf = append(buf, byte('0'+(c.col/perSquare)%10), byte('0'+(c.row/perSquare)%10))
+	}
+	if c.prec >= PrecisionSubsquare {
+		buf = append(buf, byte('a'+c.col%24), byte('a'+c.row%24))
+	}
+	return string(buf)
+}
+
+// truncate returns the cell at precision p that contains c. p must not be finer than c's precision.
+func (c cell) truncate(p Precision) cell {
+	scale := c.prec.divisions() / p.divisions()
+	return cell{col: c.col / scale, row: c.row / scale, prec: p}
+}
+
+// bounds returns the latitude/longitude extent of the cell.
+func (c cell) bounds() BoundingBox {
+	w := c.prec.cellWidth()
+	h := c.prec.cellHeight()
+	return BoundingBox{
+		MinLat: float64(c.row)*h - 90.0,
+		MinLon: float64(c.col)*w - 180.0,
+		MaxLat: float64(c.row+1)*h - 90.0,
+		MaxLon: float64(c.col+1)*w - 180.0,
+	}
+}
+
+// center returns the latitude and longitude of the cell centre, rounded to 5 decimal places.
+func (c cell) center() (float64, float64) {
+	b := c.bounds()
+	lat := (b.MinLat + b.MaxLat) / 2
+	lon := (b.MinLon + b.MaxLon) / 2
+	return math.Round(lat*rounding) / rounding, math.Round(lon*rounding) / rounding
+}
@@ -0,0 +1,76 @@
+package maidenhead
+
+import "testing"
+
+func TestCell_LocatorAndCenter(t *testing.T) {
+	cases := []struct {
+		c    cell
+		want string
+	}{
+		{cell{col: 0, row: 0, prec: PrecisionField}, "AA"},
+		{cell{col: 17, row: 17, prec: PrecisionField}, "RR"},
+		{cell{col: 95, row: 138, prec: PrecisionSquare}, "JN58"},
+		{cell{col: 95*24 + 19, row: 138*24 + 3, prec: PrecisionSubsquare}, "JN58td"},
+	}
+	for _, tc := range cases {
+		if got := tc.c.locator(); got != tc.want {
+			t.Errorf("locator(%+v) got %q want %q", tc.c, got, tc.want)
+		}
+	}
+
+	// Subsquare centre must agree with the 6-character conversion functions
+	lat, lon := cases[3].c.center()
+	expLat, _ := LatitudeFromGridSquare("JN58td")
+	expLon, _ := LongitudeFromGridSquare("JN58td")
+	if !almostEqual(lat, expLat, 1e-5) || !almostEqual(lon, expLon, 1e-5) {
+		t.Errorf("center got (%.5f,%.5f) want (%.5f,%.5f)", lat, lon, expLat, expLon)
+	}
+}
+
+func TestPrecision_Valid(t *testing.T) {
+	for _, p := range []Precision{PrecisionField, PrecisionSquare, PrecisionSubsquare} {
+		if !p.Valid() {
+			t.Errorf("%s should be valid", p)
+		}
+	}
+	for _, p := range []Precision{0, 1, 3, 8} {
+		if p.Valid() {
+			t.Errorf("%s should not be valid", p)
+		}
+	}
+}
+
+func TestParseLocator(t *testing.T) {
+	for _, loc := range []string{"jn", "JN58", "jn58TD"} {
+		c, err := parseLocator(loc)
+		if err != nil {
+			t.Fatalf("parseLocator(%q) error: %v", loc, err)
+		}
+		if got := c.locator(); got != normalizeLocator(loc) {
+			t.Errorf("parseLocator(%q) round trip got %q", loc, got)
+		}
+	}
+	for _, bad := range []string{"", "J", "JN5", "JN58t", "JN58tdx", "JNab", "SR"} {
+		if _, err := parseLocator(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestCellAt(t *testing.T) {
+	tests := []struct {
+		lat, lon float64
+		p        Precision
+		want     string
+	}{
+		{48.14666, 11.60833, PrecisionSubsquare, "JN58td"},
+		{-90, -180, PrecisionSquare, "AA00"},
+		{90, 180, PrecisionSquare, "AR09"},
+		{55.5, 12.5, PrecisionField, "JO"},
+	}
+	for _, tt := range tests {
+		if got := cellAt(tt.lat, tt.lon, tt.p).locator(); got != tt.want {
+			t.Errorf("cellAt(%v, %v, %s) got %s want %s", tt.lat, tt.lon, tt.p, got, tt.want)
+		}
+	}
+}
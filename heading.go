@@ -0,0 +1,133 @@
+package maidenhead
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// WeightedTarget is a target locator with the value of working it, such as 1 for an active spot or a higher number
+// for a needed grid.
+type WeightedTarget struct {
+	GridSquare string  `json:"gridSquare"`
+	Weight     float64 `json:"weight"`
+}
+
+// BeamCoverage is one suggested antenna heading and the targets inside its beam.
+type BeamCoverage struct {
+	Heading float64  `json:"heading"`
+	Weight  float64  `json:"weight"`
+	Targets []string `json:"targets"`
+}
+
+// HeadingSuggestion is the result of GetBestHeadings.
+type HeadingSuggestion struct {
+	LocalGridSquare string         `json:"localGridSquare"`
+	Beamwidth       float64        `json:"beamwidth"`
+	Beams           []BeamCoverage `json:"beams"`
+	CoveredWeight   float64        `json:"covered_weight"`
+	TotalWeight     float64        `json:"total_weight"`
+}
+
+// GetBestHeadings finds the antenna headings that cover the most target weight, where a target is covered if its
+// short path bearing lies within half the beamwidth of a heading. For a single antenna the result is optimal and the
+// heading is centred on the covered targets. For several antennas, such as a stack whose antennas can be pointed
+// separately, each further heading is chosen greedily to cover the most weight not already covered; headings that
+// would cover nothing are omitted. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead Grid Square of the local station (6 characters)
+//   - targets: The target grid squares (6 characters) and their weights; zero weights are ignored
+//   - beamwidth: The antenna's -3 dB beamwidth in degrees (0-360)
+//   - antennas: The number of independently pointed antennas, 1 for a single rotator
+//
+// Returns:
+//   - *HeadingSuggestion: The headings, most weight first, with the targets each covers
+//   - error: An error if any grid square, weight, the beamwidth or the number of antennas is invalid
+func GetBestHeadings(localGridSquare string, targets []WeightedTarget, beamwidth float64, antennas int) (*HeadingSuggestion, error) {
+	if beamwidth <= 0 || beamwidth > 360 || math.IsNaN(beamwidth) {
+		return nil, fmt.Errorf("invalid beamwidth: %v (must be 0-360)", beamwidth)
+	}
+	if antennas < 1 {
+		return nil, fmt.Errorf("invalid number of antennas: %d (must be at least 1)", antennas)
+	}
+
+	suggestion := &HeadingSuggestion{LocalGridSquare: localGridSquare, Beamwidth: beamwidth}
+	var remaining []bearingTarget
+	for i, t := range targets {
+		if t.Weight < 0 || math.IsNaN(t.Weight) || math.IsInf(t.Weight, 0) {
+			return nil, fmt.Errorf("target %d: invalid weight: %v", i+1, t.Weight)
+		}
+		bearing, err := GetShortPathBearing(localGridSquare, t.GridSquare)
+		if err != nil {
+			return nil, fmt.Errorf("target %d: %w", i+1, err)
+		}
+		if t.Weight == 0 {
+			continue
+		}
+		remaining = append(remaining, bearingTarget{WeightedTarget: t, bearing: bearing})
+		suggestion.TotalWeight += t.Weight
+	}
+	slices.SortStableFunc(remaining, func(a, b bearingTarget) int { return cmp.Compare(a.bearing, b.bearing) })
+
+	for range antennas {
+		if len(remaining) == 0 {
+			break
+		}
+		beam, covered := bestBeam(remaining, beamwidth)
+		suggestion.Beams = append(suggestion.Beams, beam)
+		suggestion.CoveredWeight += beam.Weight
+		remaining = slices.DeleteFunc(remaining, func(t bearingTarget) bool { return covered[t.GridSquare] })
+	}
+	suggestion.CoveredWeight = math.Round(suggestion.CoveredWeight*1000) / 1000
+	suggestion.TotalWeight = math.Round(suggestion.TotalWeight*1000) / 1000
+	return suggestion, nil
+}
+
+// bearingTarget is a weighted target with its bearing from the local station.
+type bearingTarget struct {
+	WeightedTarget
+	bearing float64
+}
+
+// bestBeam returns the heading covering the most weight of targets, which must be ordered by bearing, and the set of
+// grid squares it covers. An optimal beam can always be turned until its counterclockwise edge meets a target, so
+// each target is tried as that edge; ties go to the beam whose targets are least spread out.
+func bestBeam(targets []bearingTarget, beamwidth float64) (BeamCoverage, map[string]bool) {
+	n := len(targets)
+	bestStart, bestCount := 0, 0
+	bestWeight, bestSpread := -1.0, 0.0
+	for i := range targets {
+		if i > 0 && targets[i-1].bearing == targets[i].bearing {
+			continue // Already counted from the first target on this bearing
+		}
+		var weight, spread float64
+		count := 0
+		for j := range n {
+			t := targets[(i+j)%n]
+			offset := math.Mod(t.bearing-targets[i].bearing+360, 360)
+			if offset > beamwidth {
+				break
+			}
+			weight += t.Weight
+			spread = offset
+			count++
+		}
+		if weight > bestWeight || (weight == bestWeight && spread < bestSpread) {
+			bestStart, bestCount, bestWeight, bestSpread = i, count, weight, spread
+		}
+	}
+
+	beam := BeamCoverage{
+		Heading: math.Mod(math.Round(math.Mod(targets[bestStart].bearing+bestSpread/2, 360)*10)/10, 360),
+		Weight:  math.Round(bestWeight*1000) / 1000,
+	}
+	covered := make(map[string]bool, bestCount)
+	for j := range bestCount {
+		t := targets[(bestStart+j)%n]
+		beam.Targets = append(beam.Targets, t.GridSquare)
+		covered[t.GridSquare] = true
+	}
+	return beam, covered
+}
@@ -0,0 +1,87 @@
+package maidenhead
+
+import (
+	"slices"
+	"testing"
+)
+
+// Bearings from JN58td: JO59jw 358°, JO62qm 13.7°, KP20le 27.7° to the north; JN18du 280°, FN31pr 297.7°,
+// IO91wm 298.4°, JO31nf 318.3° to the west; KN04fr 116° and JN45ok 212.3° on their own.
+func contestTargets(northWeight float64) []WeightedTarget {
+	return []WeightedTarget{
+		{"JO59jw", northWeight}, {"JO62qm", northWeight}, {"KP20le", northWeight},
+		{"JN18du", 1}, {"FN31pr", 1}, {"IO91wm", 1}, {"JO31nf", 1},
+		{"KN04fr", 1}, {"JN45ok", 1},
+	}
+}
+
+func TestGetBestHeadings(t *testing.T) {
+	tests := []struct {
+		name        string
+		northWeight float64
+		heading     float64
+		covered     []string
+	}{
+		{"west cluster has more targets", 1, 299.2, []string{"JN18du", "FN31pr", "IO91wm", "JO31nf"}},
+		{"north cluster across 0° has more weight", 2, 12.9, []string{"JO59jw", "JO62qm", "KP20le"}},
+	}
+	for _, tt := range tests {
+		got, err := GetBestHeadings("JN58td", contestTargets(tt.northWeight), 60, 1)
+		if err != nil {
+			t.Fatalf("%s: GetBestHeadings error: %v", tt.name, err)
+		}
+		if len(got.Beams) != 1 {
+			t.Fatalf("%s: got %d beams want 1", tt.name, len(got.Beams))
+		}
+		beam := got.Beams[0]
+		if !almostEqual(beam.Heading, tt.heading, 0.1) || !slices.Equal(beam.Targets, tt.covered) {
+			t.Errorf("%s: got heading %.1f covering %v, want %.1f covering %v", tt.name, beam.Heading, beam.Targets,
+				tt.heading, tt.covered)
+		}
+		if got.TotalWeight != 6+3*tt.northWeight || got.CoveredWeight != beam.Weight {
+			t.Errorf("%s: unexpected weights: %+v", tt.name, got)
+		}
+	}
+}
+
+func TestGetBestHeadingsStacked(t *testing.T) {
+	got, err := GetBestHeadings("JN58td", contestTargets(2), 60, 2)
+	if err != nil {
+		t.Fatalf("GetBestHeadings error: %v", err)
+	}
+	if len(got.Beams) != 2 || !almostEqual(got.Beams[0].Heading, 12.9, 0.1) || !almostEqual(got.Beams[1].Heading, 299.2, 0.1) {
+		t.Errorf("unexpected beams: %+v", got.Beams)
+	}
+	if got.CoveredWeight != 10 || got.TotalWeight != 12 {
+		t.Errorf("covered %v of %v want 10 of 12", got.CoveredWeight, got.TotalWeight)
+	}
+
+	// More antennas than needed: beams that would cover nothing are omitted
+	targets := []WeightedTarget{{"JO62qm", 1}, {"KP20le", 1}, {"JN45ok", 0}}
+	got, err = GetBestHeadings("JN58td", targets, 30, 3)
+	if err != nil {
+		t.Fatalf("GetBestHeadings error: %v", err)
+	}
+	if len(got.Beams) != 1 || got.CoveredWeight != 2 || !almostEqual(got.Beams[0].Heading, 20.7, 0.1) {
+		t.Errorf("unexpected suggestion: %+v", got)
+	}
+}
+
+func TestGetBestHeadingsErrors(t *testing.T) {
+	targets := []WeightedTarget{{"JO62qm", 1}}
+	if _, err := GetBestHeadings("JN58td", targets, 0, 1); err == nil {
+		t.Error("expected error for zero beamwidth")
+	}
+	if _, err := GetBestHeadings("JN58td", targets, 60, 0); err == nil {
+		t.Error("expected error for no antennas")
+	}
+	if _, err := GetBestHeadings("JN58td", []WeightedTarget{{"JO62qm", -1}}, 60, 1); err == nil {
+		t.Error("expected error for negative weight")
+	}
+	if _, err := GetBestHeadings("JN58td", []WeightedTarget{{"JO62", 1}}, 60, 1); err == nil {
+		t.Error("expected error for invalid target")
+	}
+	if got, err := GetBestHeadings("JN58td", nil, 60, 1); err != nil || len(got.Beams) != 0 {
+		t.Errorf("expected no beams without targets, got %+v, %v", got, err)
+	}
+}
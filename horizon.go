@@ -0,0 +1,109 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultKFactor is the effective Earth radius factor for a standard atmosphere, used when a k-factor of 0 is given.
+const DefaultKFactor = 4.0 / 3.0
+
+const clearanceSamples = 100 // Number of segments a path is split into when finding the minimum clearance
+
+// LineOfSight describes whether two antennas can see each other over a smooth Earth.
+type LineOfSight struct {
+	LocalGridSquare      string  `json:"localGridSquare"`
+	RemoteGridSquare     string  `json:"remoteGridSquare"`
+	LocalAntennaHeightM  float64 `json:"local_antenna_height_m"`
+	RemoteAntennaHeightM float64 `json:"remote_antenna_height_m"`
+	KFactor              float64 `json:"k_factor"`
+	DistanceKm           float64 `json:"distance_km"`
+	LocalHorizonKm       float64 `json:"local_horizon_km"`
+	RemoteHorizonKm      float64 `json:"remote_horizon_km"`
+	// RadioHorizonKm is the longest path with line of sight: the sum of both horizons.
+	RadioHorizonKm float64 `json:"radio_horizon_km"`
+	LineOfSight    bool    `json:"line_of_sight"`
+	// ClearanceM is the smallest height of the direct ray above the Earth's bulge; negative when the Earth blocks it.
+	ClearanceM float64 `json:"clearance_m"`
+}
+
+// RadioHorizonKm returns the distance in kilometers to the radio horizon of an antenna heightM meters above a smooth
+// Earth, for an effective Earth radius factor kFactor (0 selects DefaultKFactor).
+func RadioHorizonKm(heightM, kFactor float64) float64 {
+	if kFactor == 0 {
+		kFactor = DefaultKFactor
+	}
+	return math.Sqrt(2 * kFactor * earthRad * math.Max(heightM, 0) / 1000)
+}
+
+// GetLineOfSight calculates the radio horizons of two antennas and whether there is line of sight between them over a
+// smooth Earth, using the distance from GetShortPathDistance. Terrain is ignored. Grid square input is
+// case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead Grid Square of the local station (6 characters)
+//   - remoteGridSquare: The Maidenhead Grid Square of the remote station (6 characters)
+//   - localHeightM: The local antenna height above ground in meters
+//   - remoteHeightM: The remote antenna height above ground in meters
+//   - kFactor: The effective Earth radius factor, or 0 for DefaultKFactor (4/3)
+//
+// Returns:
+//   - *LineOfSight: The horizons, line-of-sight result and clearance
+//   - error: An error if either grid square, either height or the k-factor is invalid
+func GetLineOfSight(localGridSquare, remoteGridSquare string, localHeightM, remoteHeightM, kFactor float64) (*LineOfSight, error) {
+	kFactor, err := validateLinkGeometry(localHeightM, remoteHeightM, kFactor)
+	if err != nil {
+		return nil, err
+	}
+	distanceKm, _, err := GetShortPathDistance(localGridSquare, remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate short path distance: %w", err)
+	}
+
+	los := &LineOfSight{
+		LocalGridSquare:      localGridSquare,
+		RemoteGridSquare:     remoteGridSquare,
+		LocalAntennaHeightM:  localHeightM,
+		RemoteAntennaHeightM: remoteHeightM,
+		KFactor:              kFactor,
+		DistanceKm:           distanceKm,
+		LocalHorizonKm:       math.Round(RadioHorizonKm(localHeightM, kFactor)*10) / 10,
+		RemoteHorizonKm:      math.Round(RadioHorizonKm(remoteHeightM, kFactor)*10) / 10,
+	}
+	horizon := RadioHorizonKm(localHeightM, kFactor) + RadioHorizonKm(remoteHeightM, kFactor)
+	los.RadioHorizonKm = math.Round(horizon*10) / 10
+	los.LineOfSight = distanceKm <= horizon
+
+	clearance := math.Inf(1)
+	for i := 0; i <= clearanceSamples; i++ {
+		d1 := distanceKm * float64(i) / clearanceSamples
+		ray := localHeightM + (remoteHeightM-localHeightM)*float64(i)/clearanceSamples
+		clearance = math.Min(clearance, ray-earthBulgeM(d1, distanceKm-d1, kFactor))
+	}
+	los.ClearanceM = math.Round(clearance*10) / 10
+	return los, nil
+}
+
+// validateLinkGeometry checks the antenna heights and k-factor of a terrestrial path, returning the k-factor with 0
+// replaced by DefaultKFactor.
+func validateLinkGeometry(localHeightM, remoteHeightM, kFactor float64) (float64, error) {
+	for _, h := range []float64{localHeightM, remoteHeightM} {
+		if h < 0 || math.IsNaN(h) || math.IsInf(h, 0) {
+			return 0, fmt.Errorf("invalid antenna height: %.1f m, %.1f m (must be finite and not negative)",
+				localHeightM, remoteHeightM)
+		}
+	}
+	if kFactor == 0 {
+		kFactor = DefaultKFactor
+	}
+	if kFactor < 0 || math.IsNaN(kFactor) || math.IsInf(kFactor, 0) {
+		return 0, fmt.Errorf("invalid k-factor: %v (must be positive and finite)", kFactor)
+	}
+	return kFactor, nil
+}
+
+// earthBulgeM returns the height in meters of the effective Earth's surface above the straight chord between two
+// points, at a point d1Km from one end and d2Km from the other.
+func earthBulgeM(d1Km, d2Km, kFactor float64) float64 {
+	return d1Km * d2Km / (2 * kFactor * earthRad) * 1000
+}
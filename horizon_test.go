@@ -0,0 +1,93 @@
+package maidenhead
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRadioHorizonKm(t *testing.T) {
+	// The familiar rule of thumb: 4.12 km per square root of meters for k = 4/3
+	if got := RadioHorizonKm(100, DefaultKFactor); !almostEqual(got, 41.2, 0.1) {
+		t.Errorf("100 m got %.2f km want ~41.2", got)
+	}
+	if got := RadioHorizonKm(100, 0); got != RadioHorizonKm(100, DefaultKFactor) {
+		t.Errorf("k-factor 0 should select the default, got %.2f", got)
+	}
+	if got := RadioHorizonKm(100, 1); !almostEqual(got, 35.7, 0.1) {
+		t.Errorf("geometric horizon got %.2f km want ~35.7", got)
+	}
+	if got := RadioHorizonKm(0, DefaultKFactor); got != 0 {
+		t.Errorf("zero height got %.2f want 0", got)
+	}
+}
+
+func TestEarthBulgeM(t *testing.T) {
+	// Midpoint bulge of a 50 km path with k = 4/3 is about 37 m
+	if got := earthBulgeM(25, 25, DefaultKFactor); !almostEqual(got, 36.8, 0.1) {
+		t.Errorf("got %.2f m want ~36.8", got)
+	}
+	if got := earthBulgeM(0, 50, DefaultKFactor); got != 0 {
+		t.Errorf("bulge at the end of the path got %.2f want 0", got)
+	}
+}
+
+func TestGetLineOfSight(t *testing.T) {
+	// JN58td to JN58vd is about 6 km
+	los, err := GetLineOfSight("JN58td", "JN58vd", 10, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLineOfSight error: %v", err)
+	}
+	if !los.LineOfSight || los.ClearanceM <= 0 || los.KFactor != DefaultKFactor {
+		t.Errorf("expected line of sight over a short path: %+v", los)
+	}
+	if km, _, _ := GetShortPathDistance("JN58td", "JN58vd"); los.DistanceKm != km {
+		t.Errorf("distance got %.0f want %.0f", los.DistanceKm, km)
+	}
+
+	// About 150 km needs tall masts
+	low, err := GetLineOfSight("JN58td", "JN68td", 10, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLineOfSight error: %v", err)
+	}
+	if low.LineOfSight || low.ClearanceM >= 0 {
+		t.Errorf("expected no line of sight with 10 m masts: %+v", low)
+	}
+	high, err := GetLineOfSight("JN58td", "JN68td", 400, 400, 0)
+	if err != nil {
+		t.Fatalf("GetLineOfSight error: %v", err)
+	}
+	if !high.LineOfSight || high.ClearanceM <= 0 {
+		t.Errorf("expected line of sight with 400 m masts: %+v", high)
+	}
+	if !almostEqual(high.RadioHorizonKm, high.LocalHorizonKm+high.RemoteHorizonKm, 0.11) {
+		t.Errorf("radio horizon should be the sum of both horizons: %+v", high)
+	}
+
+	// Sub-refraction (k < 1) shortens the horizon
+	sub, err := GetLineOfSight("JN58td", "JN68td", 400, 400, 0.7)
+	if err != nil {
+		t.Fatalf("GetLineOfSight error: %v", err)
+	}
+	if sub.RadioHorizonKm >= high.RadioHorizonKm || sub.ClearanceM >= high.ClearanceM {
+		t.Errorf("k=0.7 should shorten the horizon: %+v vs %+v", sub, high)
+	}
+
+	if _, err := GetLineOfSight("JN58td", "JN68td", -1, 10, 0); err == nil {
+		t.Errorf("expected error for negative height")
+	}
+	if _, err := GetLineOfSight("JN58td", "JN68td", 10, math.NaN(), 0); err == nil {
+		t.Errorf("expected error for NaN height")
+	}
+	if _, err := GetLineOfSight("JN58td", "JN68td", math.Inf(1), 10, 0); err == nil {
+		t.Errorf("expected error for infinite height")
+	}
+	if _, err := GetLineOfSight("JN58td", "JN68td", 10, 10, math.Inf(1)); err == nil {
+		t.Errorf("expected error for infinite k-factor")
+	}
+	if _, err := GetLineOfSight("JN58td", "JN68td", 10, 10, -1); err == nil {
+		t.Errorf("expected error for negative k-factor")
+	}
+	if _, err := GetLineOfSight("JN58", "JN68td", 10, 10, 0); err == nil {
+		t.Errorf("expected error for 4-character locator")
+	}
+}
@@ -0,0 +1,112 @@
+package maidenhead
+
+import (
+	"fmt"
+	"time"
+)
+
+const illuminationSamples = 100 // Number of segments a path is split into when sampling solar elevation
+
+// Lighting classifies the Sun's elevation at a point.
+type Lighting int
+
+const (
+	Daylight Lighting = iota // Sun above the horizon (above -0.833°)
+	Twilight                 // Sun in the grey line band (-6° to -0.833°)
+	Darkness                 // Sun below civil twilight (below -6°)
+)
+
+// String returns a human-readable name for the lighting condition.
+func (l Lighting) String() string {
+	switch l {
+	case Daylight:
+		return "daylight"
+	case Twilight:
+		return "twilight"
+	case Darkness:
+		return "darkness"
+	default:
+		return fmt.Sprintf("Lighting(%d)", int(l))
+	}
+}
+
+// lightingFor classifies a solar elevation in degrees, using the same bands as GetGreylineWindows.
+func lightingFor(elevation float64) Lighting {
+	switch {
+	case elevation > sunriseAltitude:
+		return Daylight
+	case inGreyline(elevation):
+		return Twilight
+	default:
+		return Darkness
+	}
+}
+
+// PathPoint is a sample along a path with the Sun's elevation at that point.
+type PathPoint struct {
+	Latitude       float64  `json:"latitude"`
+	Longitude      float64  `json:"longitude"`
+	DistanceKm     float64  `json:"distance_km"`
+	SolarElevation float64  `json:"solar_elevation"`
+	Lighting       Lighting `json:"lighting"`
+}
+
+// PathIllumination describes how much of a path between two locators is in daylight, twilight and darkness.
+// The fractions are of the sampled points and sum to 1.
+type PathIllumination struct {
+	LocalGridSquare  string      `json:"localGridSquare"`
+	RemoteGridSquare string      `json:"remoteGridSquare"`
+	PathType         PathType    `json:"path_type"`
+	Time             time.Time   `json:"time"`
+	Points           []PathPoint `json:"points"`
+	DaylightFraction float64     `json:"daylight_fraction"`
+	TwilightFraction float64     `json:"twilight_fraction"`
+	DarknessFraction float64     `json:"darkness_fraction"`
+}
+
+// GetPathIllumination calculates the solar elevation at evenly spaced points along the short or long path between
+// two locators at a given time and reports the fraction of the path in daylight, twilight and darkness.
+// Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead locator of the local station (2, 4 or 6 characters)
+//   - remoteGridSquare: The Maidenhead locator of the remote station (2, 4 or 6 characters)
+//   - pathType: ShortPath or LongPath
+//   - at: The time of interest
+//
+// Returns:
+//   - *PathIllumination: The sampled points, from local to remote, and the lighting fractions
+//   - error: An error if either locator or the path type is invalid
+func GetPathIllumination(localGridSquare, remoteGridSquare string, pathType PathType, at time.Time) (*PathIllumination, error) {
+	path, err := newGreatCirclePath(localGridSquare, remoteGridSquare, pathType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PathIllumination{
+		LocalGridSquare:  localGridSquare,
+		RemoteGridSquare: remoteGridSquare,
+		PathType:         pathType,
+		Time:             at.UTC(),
+	}
+
+	counts := map[Lighting]int{}
+	for _, s := range path.samples(illuminationSamples) {
+		elevation, _ := sunPosition(s[0], s[1], at)
+		lighting := lightingFor(elevation)
+		counts[lighting]++
+		result.Points = append(result.Points, PathPoint{
+			Latitude:       s[0],
+			Longitude:      s[1],
+			DistanceKm:     s[2],
+			SolarElevation: elevation,
+			Lighting:       lighting,
+		})
+	}
+
+	total := float64(len(result.Points))
+	result.DaylightFraction = float64(counts[Daylight]) / total
+	result.TwilightFraction = float64(counts[Twilight]) / total
+	result.DarknessFraction = float64(counts[Darkness]) / total
+	return result, nil
+}
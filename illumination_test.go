@@ -0,0 +1,58 @@
+package maidenhead
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetPathIllumination_Fractions(t *testing.T) {
+	at := time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)
+	short, err := GetPathIllumination("JN58td", "FN31pr", ShortPath, at)
+	if err != nil {
+		t.Fatalf("GetPathIllumination error: %v", err)
+	}
+	if len(short.Points) != illuminationSamples+1 {
+		t.Fatalf("points got %d want %d", len(short.Points), illuminationSamples+1)
+	}
+	sum := short.DaylightFraction + short.TwilightFraction + short.DarknessFraction
+	if !almostEqual(sum, 1, 1e-9) {
+		t.Errorf("fractions sum got %.6f want 1", sum)
+	}
+	// Midday UTC at midsummer: the whole transatlantic path is sunlit
+	if short.DaylightFraction != 1 {
+		t.Errorf("short path daylight fraction got %.2f want 1", short.DaylightFraction)
+	}
+
+	long, err := GetPathIllumination("JN58td", "FN31pr", LongPath, at)
+	if err != nil {
+		t.Fatalf("GetPathIllumination error: %v", err)
+	}
+	if long.DarknessFraction <= 0 {
+		t.Errorf("long path should cross the night side, got %+v", long.DarknessFraction)
+	}
+
+	// First point is the local station
+	lat, lon, _ := locatorCenter("JN58td")
+	elev, _ := sunPosition(lat, lon, at)
+	if !almostEqual(short.Points[0].SolarElevation, elev, 1e-9) || short.Points[0].DistanceKm != 0 {
+		t.Errorf("first point mismatch: %+v", short.Points[0])
+	}
+}
+
+func TestLightingFor(t *testing.T) {
+	cases := map[float64]Lighting{10: Daylight, 0: Daylight, -0.833: Twilight, -3: Twilight, -6: Twilight, -6.1: Darkness, -40: Darkness}
+	for elev, want := range cases {
+		if got := lightingFor(elev); got != want {
+			t.Errorf("lightingFor(%.3f) got %s want %s", elev, got, want)
+		}
+	}
+}
+
+func TestGetPathIllumination_Errors(t *testing.T) {
+	if _, err := GetPathIllumination("BAD", "FN31pr", ShortPath, time.Now()); err == nil {
+		t.Errorf("expected error for bad local locator")
+	}
+	if _, err := GetPathIllumination("JN58td", "FN31pr", PathType(3), time.Now()); err == nil {
+		t.Errorf("expected error for bad path type")
+	}
+}
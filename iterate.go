@@ -0,0 +1,75 @@
+package maidenhead
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Fields returns an iterator over all 324 fields (AA to RR) in lexical order.
+func Fields() iter.Seq[string] {
+	return locators(PrecisionField, nil)
+}
+
+// Squares returns an iterator over all 32,400 squares (AA00 to RR99) in lexical order.
+func Squares() iter.Seq[string] {
+	return locators(PrecisionSquare, nil)
+}
+
+// Subsquares returns an iterator over all 18,662,400 subsquares (AA00aa to RR99xx) in lexical order.
+func Subsquares() iter.Seq[string] {
+	return locators(PrecisionSubsquare, nil)
+}
+
+// LocatorsWithin returns an iterator over every locator of the given precision whose cell overlaps the bounding box,
+// in lexical order. Boxes with MinLon greater than MaxLon are treated as crossing the antimeridian.
+//
+// Parameters:
+//   - precision: The locator precision to iterate (PrecisionField, PrecisionSquare or PrecisionSubsquare)
+//   - box: The latitude/longitude area of interest
+//
+// Returns:
+//   - iter.Seq[string]: An iterator yielding canonical (AA99aa) locators
+//   - error: An error if the precision or bounding box is invalid
+func LocatorsWithin(precision Precision, box BoundingBox) (iter.Seq[string], error) {
+	if !precision.Valid() {
+		return nil, fmt.Errorf("unsupported precision: %s", precision)
+	}
+	if err := box.validate(); err != nil {
+		return nil, err
+	}
+	return locators(precision, &box), nil
+}
+
+// locators adapts walkCells to an iterator of locator strings.
+func locators(precision Precision, box *BoundingBox) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		walkCells(PrecisionField, 0, 0, precision, box, func(c cell) bool {
+			return yield(c.locator())
+		})
+	}
+}
+
+// walkCells visits the children of the cell (col, row) at the given level in lexical order, descending until
+// the target precision is reached. Cells that do not overlap box (when non-nil) are pruned along with their children.
+// It returns false if yield asked to stop.
+func walkCells(level Precision, col, row int, target Precision, box *BoundingBox, yield func(cell) bool) bool {
+	n := level.subdivisions()
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			c := cell{col: col*n + i, row: row*n + j, prec: level}
+			if box != nil && !box.overlaps(c.bounds()) {
+				continue
+			}
+			if level == target {
+				if !yield(c) {
+					return false
+				}
+				continue
+			}
+			if !walkCells(level+2, c.col, c.row, target, box, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package maidenhead
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestFields_CountAndOrder(t *testing.T) {
+	all := slices.Collect(Fields())
+	if len(all) != 324 {
+		t.Fatalf("Fields count got %d want 324", len(all))
+	}
+	if all[0] != "AA" || all[1] != "AB" || all[len(all)-1] != "RR" {
+		t.Errorf("unexpected field order: first=%q second=%q last=%q", all[0], all[1], all[len(all)-1])
+	}
+	if !sort.StringsAreSorted(all) {
+		t.Errorf("fields not in lexical order")
+	}
+}
+
+func TestSquares_CountAndOrder(t *testing.T) {
+	all := slices.Collect(Squares())
+	if len(all) != 32400 {
+		t.Fatalf("Squares count got %d want 32400", len(all))
+	}
+	if all[0] != "AA00" || all[1] != "AA01" || all[10] != "AA10" || all[len(all)-1] != "RR99" {
+		t.Errorf("unexpected square order: %q %q %q %q", all[0], all[1], all[10], all[len(all)-1])
+	}
+	if !sort.StringsAreSorted(all) {
+		t.Errorf("squares not in lexical order")
+	}
+}
+
+func TestSubsquares_PrefixAndEarlyStop(t *testing.T) {
+	var got []string
+	for s := range Subsquares() {
+		got = append(got, s)
+		if len(got) == 26 {
+			break
+		}
+	}
+	if got[0] != "AA00aa" || got[1] != "AA00ab" || got[23] != "AA00ax" || got[24] != "AA00ba" {
+		t.Errorf("unexpected subsquare order: %v", got)
+	}
+	for _, s := range got {
+		if err := validateInput(s); err != nil {
+			t.Errorf("subsquare %q failed validation: %v", s, err)
+		}
+	}
+}
+
+func TestLocatorsWithin_Box(t *testing.T) {
+	// Exactly field JN: should not pick up neighbouring fields on the shared edges
+	seq, err := LocatorsWithin(PrecisionField, BoundingBox{MinLat: 40, MinLon: 0, MaxLat: 50, MaxLon: 20})
+	if err != nil {
+		t.Fatalf("LocatorsWithin error: %v", err)
+	}
+	if got := slices.Collect(seq); !slices.Equal(got, []string{"JN"}) {
+		t.Errorf("field box got %v want [JN]", got)
+	}
+
+	// Squares covering roughly southern England
+	seq, err = LocatorsWithin(PrecisionSquare, BoundingBox{MinLat: 50.5, MinLon: -3, MaxLat: 51.5, MaxLon: 1})
+	if err != nil {
+		t.Fatalf("LocatorsWithin error: %v", err)
+	}
+	got := slices.Collect(seq)
+	want := []string{"IO80", "IO81", "IO90", "IO91", "JO00", "JO01"}
+	if !slices.Equal(got, want) {
+		t.Errorf("square box got %v want %v", got, want)
+	}
+
+	// Subsquares in a single point
+	seq, err = LocatorsWithin(PrecisionSubsquare, BoundingBox{MinLat: 48.1458, MinLon: 11.625, MaxLat: 48.1458, MaxLon: 11.625})
+	if err != nil {
+		t.Fatalf("LocatorsWithin error: %v", err)
+	}
+	if got := slices.Collect(seq); !slices.Equal(got, []string{"JN58td"}) {
+		t.Errorf("point box got %v want [JN58td]", got)
+	}
+}
+
+func TestLocatorsWithin_Antimeridian(t *testing.T) {
+	seq, err := LocatorsWithin(PrecisionField, BoundingBox{MinLat: 0, MinLon: 170, MaxLat: 10, MaxLon: -170})
+	if err != nil {
+		t.Fatalf("LocatorsWithin error: %v", err)
+	}
+	if got := slices.Collect(seq); !slices.Equal(got, []string{"AJ", "RJ"}) {
+		t.Errorf("antimeridian box got %v want [AJ RJ]", got)
+	}
+}
+
+func TestLocatorsWithin_Errors(t *testing.T) {
+	if _, err := LocatorsWithin(Precision(3), BoundingBox{MaxLat: 1, MaxLon: 1}); err == nil {
+		t.Errorf("expected error for unsupported precision")
+	}
+	if _, err := LocatorsWithin(PrecisionSquare, BoundingBox{MinLat: 10, MaxLat: 0}); err == nil {
+		t.Errorf("expected error for inverted latitude range")
+	}
+	if _, err := LocatorsWithin(PrecisionSquare, BoundingBox{MinLon: -181, MaxLon: 0}); err == nil {
+		t.Errorf("expected error for out of range longitude")
+	}
+}
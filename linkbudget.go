@@ -0,0 +1,201 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	boltzmannDBmHz     = -174.0        // Thermal noise density at 290 K in dBm per hertz
+	moonRadiusKm       = 1737.4        // Mean radius of the Moon
+	moonReflectivity   = 0.065         // Fraction of the Moon's disc area that is effective as a radar target
+	tropoEffectiveKm   = 8500.0        // Effective Earth radius for k = 4/3, used for the scatter angle
+	tropoClimateFactor = 29.73         // Meteorological factor M of ITU-R P.617 for a continental temperate climate
+	tropoAtmosphere    = 0.33          // Atmospheric structure factor γ in km⁻¹ for a continental temperate climate
+	freeSpaceConstant  = 32.4477832219 // 20·log10(4π·10⁹/c), for distances in km and frequencies in MHz
+)
+
+// LinkMode selects the path loss model of a link budget.
+type LinkMode int
+
+const (
+	FreeSpace    LinkMode = iota // Direct line-of-sight path with free-space loss
+	EME                          // Earth-Moon-Earth reflection
+	Troposcatter                 // Forward scatter from the troposphere beyond the radio horizon
+)
+
+// String returns a human-readable name for the mode.
+func (m LinkMode) String() string {
+	switch m {
+	case FreeSpace:
+		return "free space"
+	case EME:
+		return "EME"
+	case Troposcatter:
+		return "troposcatter"
+	default:
+		return fmt.Sprintf("LinkMode(%d)", int(m))
+	}
+}
+
+// LinkParams describes the stations and equipment of a link.
+type LinkParams struct {
+	Mode          LinkMode `json:"mode"`
+	FrequencyMHz  float64  `json:"frequency_mhz"`
+	TxPowerW      float64  `json:"tx_power_w"`
+	TxGainDBi     float64  `json:"tx_gain_dbi"`
+	RxGainDBi     float64  `json:"rx_gain_dbi"`
+	TxLossDB      float64  `json:"tx_loss_db"` // Feedline and connector losses at the transmitter
+	RxLossDB      float64  `json:"rx_loss_db"` // Feedline and connector losses at the receiver
+	NoiseFigureDB float64  `json:"noise_figure_db"`
+	BandwidthHz   float64  `json:"bandwidth_hz"`
+	// Time sets the Moon's position for EME; it is ignored by the other modes.
+	Time time.Time `json:"time"`
+	// TxHeightM and RxHeightM are the antenna heights above ground in meters, used by troposcatter to find the radio
+	// horizon; they are ignored by the other modes.
+	TxHeightM float64 `json:"tx_height_m"`
+	RxHeightM float64 `json:"rx_height_m"`
+}
+
+// LinkBudget is the expected signal level and SNR of a link.
+type LinkBudget struct {
+	LocalGridSquare  string   `json:"localGridSquare"`
+	RemoteGridSquare string   `json:"remoteGridSquare"`
+	Mode             LinkMode `json:"mode"`
+	FrequencyMHz     float64  `json:"frequency_mhz"`
+	// DistanceKm is the short path distance between the stations, or for EME the path length via the Moon.
+	DistanceKm float64 `json:"distance_km"`
+	PathLossDB float64 `json:"path_loss_db"`
+	EIRPDBm    float64 `json:"eirp_dbm"`
+	RxPowerDBm float64 `json:"rx_power_dbm"`
+	NoiseDBm   float64 `json:"noise_dbm"` // Receiver noise in the bandwidth at a 290 K reference temperature
+	SNRDB      float64 `json:"snr_db"`
+}
+
+// FreeSpacePathLossDB returns the free-space path loss in dB between isotropic antennas distanceKm apart at
+// frequencyMHz.
+func FreeSpacePathLossDB(distanceKm, frequencyMHz float64) float64 {
+	return 20*math.Log10(distanceKm) + 20*math.Log10(frequencyMHz) + freeSpaceConstant
+}
+
+// GetLinkBudget calculates the path loss, received signal level and SNR between two locators for a given frequency,
+// power, antenna gains and losses. The path loss follows the link mode: free space over the GetShortPathDistance
+// distance; EME as a bistatic radar echo from the Moon at the given time; or troposcatter using the ITU-R P.617 median
+// loss for a continental temperate climate and a smooth Earth, for paths beyond the radio horizon of the two antennas
+// with the default k-factor. Sky noise is not included, so EME SNRs are optimistic
+// at VHF. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead Grid Square of the transmitting station (6 characters)
+//   - remoteGridSquare: The Maidenhead Grid Square of the receiving station (6 characters)
+//   - params: The link mode, frequency, power, gains, losses, noise figure and bandwidth
+//
+// Returns:
+//   - *LinkBudget: The path loss, EIRP, received power, noise and SNR
+//   - error: An error if either grid square or any parameter is invalid, the stations are in the same subsquare, for
+//     troposcatter the path is within the radio horizon, or for EME the Moon is below the horizon at either station
+func GetLinkBudget(localGridSquare, remoteGridSquare string, params LinkParams) (*LinkBudget, error) {
+	if params.FrequencyMHz <= 0 || math.IsNaN(params.FrequencyMHz) {
+		return nil, fmt.Errorf("invalid frequency: %v MHz", params.FrequencyMHz)
+	}
+	if params.TxPowerW <= 0 || math.IsNaN(params.TxPowerW) {
+		return nil, fmt.Errorf("invalid transmit power: %v W", params.TxPowerW)
+	}
+	if params.BandwidthHz <= 0 || math.IsNaN(params.BandwidthHz) {
+		return nil, fmt.Errorf("invalid bandwidth: %v Hz", params.BandwidthHz)
+	}
+	for _, loss := range []float64{params.NoiseFigureDB, params.TxLossDB, params.RxLossDB} {
+		if loss < 0 || math.IsNaN(loss) || math.IsInf(loss, 0) {
+			return nil, fmt.Errorf("invalid losses: noise figure %v dB, TX %v dB, RX %v dB (must not be negative)",
+				params.NoiseFigureDB, params.TxLossDB, params.RxLossDB)
+		}
+	}
+	for _, gain := range []float64{params.TxGainDBi, params.RxGainDBi} {
+		if math.IsNaN(gain) || math.IsInf(gain, 0) {
+			return nil, fmt.Errorf("invalid antenna gains: TX %v dBi, RX %v dBi", params.TxGainDBi, params.RxGainDBi)
+		}
+	}
+	distanceKm, _, err := GetShortPathDistance(localGridSquare, remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate short path distance: %w", err)
+	}
+
+	budget := &LinkBudget{
+		LocalGridSquare:  localGridSquare,
+		RemoteGridSquare: remoteGridSquare,
+		Mode:             params.Mode,
+		FrequencyMHz:     params.FrequencyMHz,
+		DistanceKm:       distanceKm,
+	}
+	var loss float64
+	switch params.Mode {
+	case FreeSpace, Troposcatter:
+		if distanceKm == 0 {
+			return nil, fmt.Errorf("invalid path: %s and %s are the same subsquare", localGridSquare, remoteGridSquare)
+		}
+		if params.Mode == FreeSpace {
+			loss = FreeSpacePathLossDB(distanceKm, params.FrequencyMHz)
+		} else {
+			if _, err := validateLinkGeometry(params.TxHeightM, params.RxHeightM, 0); err != nil {
+				return nil, err
+			}
+			horizon := RadioHorizonKm(params.TxHeightM, 0) + RadioHorizonKm(params.RxHeightM, 0)
+			if distanceKm <= horizon {
+				return nil, fmt.Errorf("invalid path: %.0f km is within the %.1f km radio horizon; use free space",
+					distanceKm, horizon)
+			}
+			loss = troposcatterLossDB(distanceKm, params.FrequencyMHz, params.TxGainDBi+params.RxGainDBi)
+		}
+	case EME:
+		if params.Time.IsZero() {
+			return nil, fmt.Errorf("invalid time: EME requires the time of the contact")
+		}
+		localLat, localLon, _ := locatorCenter(localGridSquare)
+		remoteLat, remoteLon, _ := locatorCenter(remoteGridSquare)
+		ra, dec, dist := moonCoordinates(params.Time)
+		el1, _, d1 := moonTopocentric(localLat, localLon, params.Time, ra, dec, dist)
+		el2, _, d2 := moonTopocentric(remoteLat, remoteLon, params.Time, ra, dec, dist)
+		if el1 < 0 || el2 < 0 {
+			return nil, fmt.Errorf("moon below the horizon: elevation %.1f° at %s, %.1f° at %s",
+				el1, localGridSquare, el2, remoteGridSquare)
+		}
+		budget.DistanceKm = math.Round(d1 + d2)
+		loss = emeLossDB(d1, d2, params.FrequencyMHz)
+	default:
+		return nil, fmt.Errorf("invalid link mode: %s", params.Mode)
+	}
+
+	txDBm := 10*math.Log10(params.TxPowerW) + 30
+	eirp := txDBm - params.TxLossDB + params.TxGainDBi
+	rx := eirp - loss + params.RxGainDBi - params.RxLossDB
+	noise := boltzmannDBmHz + 10*math.Log10(params.BandwidthHz) + params.NoiseFigureDB
+
+	budget.PathLossDB = math.Round(loss*10) / 10
+	budget.EIRPDBm = math.Round(eirp*10) / 10
+	budget.RxPowerDBm = math.Round(rx*10) / 10
+	budget.NoiseDBm = math.Round(noise*10) / 10
+	budget.SNRDB = math.Round((rx-noise)*10) / 10
+	return budget, nil
+}
+
+// emeLossDB returns the path loss in dB of an echo from the Moon, treated as a radar target with 6.5% of its disc
+// reflecting, between stations d1Km and d2Km from it.
+func emeLossDB(d1Km, d2Km, frequencyMHz float64) float64 {
+	lambda := speedOfLightKmSec / (frequencyMHz * 1000) // Meters
+	sigma := moonReflectivity * math.Pi * math.Pow(moonRadiusKm*1000, 2)
+	d1, d2 := d1Km*1000, d2Km*1000
+	return 10 * math.Log10(math.Pow(4*math.Pi, 3)*d1*d1*d2*d2/(sigma*lambda*lambda))
+}
+
+// troposcatterLossDB returns the median basic transmission loss in dB of a troposcatter path of distanceKm over a
+// smooth Earth, including the aperture-to-medium coupling loss for the combined antenna gain gainDBi.
+func troposcatterLossDB(distanceKm, frequencyMHz, gainDBi float64) float64 {
+	theta := distanceKm / tropoEffectiveKm * 1000 // Scatter angle in mrad with both horizons at 0°
+	h := theta * distanceKm / 4 / 1000
+	hCommon := theta * theta * tropoEffectiveKm / 8 / 1e6
+	atmosphere := 20*math.Log10(5+tropoAtmosphere*h) + 4.34*tropoAtmosphere*hCommon
+	coupling := 0.07 * math.Exp(0.055*math.Max(gainDBi, 0))
+	return tropoClimateFactor + 30*math.Log10(frequencyMHz) + 10*math.Log10(distanceKm) + 30*math.Log10(theta) +
+		atmosphere + coupling
+}
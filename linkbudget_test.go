@@ -0,0 +1,147 @@
+package maidenhead
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFreeSpacePathLossDB(t *testing.T) {
+	tests := []struct {
+		distanceKm, frequencyMHz, want float64
+	}{
+		{1, 1, 32.45},
+		{100, 144, 115.6},
+		{50, 10368, 146.7},
+	}
+	for _, tt := range tests {
+		if got := FreeSpacePathLossDB(tt.distanceKm, tt.frequencyMHz); !almostEqual(got, tt.want, 0.05) {
+			t.Errorf("FreeSpacePathLossDB(%v, %v) got %.2f want %.2f", tt.distanceKm, tt.frequencyMHz, got, tt.want)
+		}
+	}
+}
+
+func TestEmeLossDB(t *testing.T) {
+	// The commonly quoted EME path loss at mean lunar distance is about 252 dB on 2 m and 271 dB on 23 cm
+	if got := emeLossDB(384400, 384400, 144); !almostEqual(got, 252.1, 0.2) {
+		t.Errorf("144 MHz got %.2f dB want ~252.1", got)
+	}
+	if got := emeLossDB(384400, 384400, 1296); !almostEqual(got, 271.2, 0.2) {
+		t.Errorf("1296 MHz got %.2f dB want ~271.2", got)
+	}
+}
+
+func TestTroposcatterLossDB(t *testing.T) {
+	// Median loss of a 300 km path on 23 cm is roughly 67 dB more than free space
+	got := troposcatterLossDB(300, 1296, 0)
+	if !almostEqual(got, 211.7, 0.5) {
+		t.Errorf("got %.2f dB want ~211.7", got)
+	}
+	if got <= FreeSpacePathLossDB(300, 1296) {
+		t.Errorf("troposcatter loss %.2f dB should exceed free-space loss", got)
+	}
+	if more := troposcatterLossDB(300, 1296, 60); more <= got {
+		t.Errorf("high-gain antennas should add coupling loss: %.2f <= %.2f", more, got)
+	}
+}
+
+func TestGetLinkBudget(t *testing.T) {
+	params := LinkParams{
+		Mode:          FreeSpace,
+		FrequencyMHz:  144.3,
+		TxPowerW:      100,
+		TxGainDBi:     10,
+		RxGainDBi:     12,
+		TxLossDB:      1,
+		RxLossDB:      2,
+		NoiseFigureDB: 1,
+		BandwidthHz:   2500,
+	}
+	budget, err := GetLinkBudget("JN58td", "jn48qd", params)
+	if err != nil {
+		t.Fatalf("GetLinkBudget error: %v", err)
+	}
+	distanceKm, _, _ := GetShortPathDistance("JN58td", "JN48qd")
+	loss := FreeSpacePathLossDB(distanceKm, 144.3)
+	if budget.DistanceKm != distanceKm || !almostEqual(budget.PathLossDB, loss, 0.05) {
+		t.Errorf("unexpected path: %+v", budget)
+	}
+	if budget.EIRPDBm != 59 || budget.NoiseDBm != -139 {
+		t.Errorf("EIRP got %.1f dBm want 59, noise got %.1f dBm want -139", budget.EIRPDBm, budget.NoiseDBm)
+	}
+	if !almostEqual(budget.RxPowerDBm, 69-loss, 0.05) || !almostEqual(budget.SNRDB, budget.RxPowerDBm+139, 0.05) {
+		t.Errorf("unexpected signal: %+v", budget)
+	}
+
+	params.Mode = Troposcatter
+	tropo, err := GetLinkBudget("JN58td", "JN48qd", params)
+	if err != nil {
+		t.Fatalf("GetLinkBudget troposcatter error: %v", err)
+	}
+	if tropo.PathLossDB <= budget.PathLossDB || tropo.SNRDB >= budget.SNRDB {
+		t.Errorf("troposcatter should lose more than free space: %+v", tropo)
+	}
+
+	// With 500 m masts the 167 km path is within the 184 km radio horizon, where troposcatter does not apply
+	params.TxHeightM, params.RxHeightM = 500, 500
+	if _, err := GetLinkBudget("JN58td", "JN48qd", params); err == nil {
+		t.Error("expected error for a troposcatter path within the radio horizon")
+	}
+	params.TxHeightM, params.RxHeightM = 0, 0
+
+	params.Mode = EME
+	params.Time = time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC) // Moon up at both stations
+	eme, err := GetLinkBudget("JN58td", "FN31pr", params)
+	if err != nil {
+		t.Fatalf("GetLinkBudget EME error: %v", err)
+	}
+	if eme.DistanceKm < 2*350000 || eme.DistanceKm > 2*410000 || eme.PathLossDB < 250 || eme.PathLossDB > 254 {
+		t.Errorf("unexpected EME path: %+v", eme)
+	}
+
+	// At 12:00 the Moon is above JN58td but below the horizon at FN31pr
+	params.Time = time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if _, err := GetLinkBudget("JN58td", "FN31pr", params); err == nil {
+		t.Error("expected error with the Moon below the horizon at the remote station")
+	}
+}
+
+func TestGetLinkBudgetErrors(t *testing.T) {
+	valid := LinkParams{FrequencyMHz: 144, TxPowerW: 100, BandwidthHz: 2500}
+	tests := []struct {
+		name          string
+		local, remote string
+		modify        func(p *LinkParams)
+	}{
+		{"invalid locator", "JN58td", "ZZ99zz", func(*LinkParams) {}},
+		{"same subsquare", "JN58td", "JN58TD", func(*LinkParams) {}},
+		{"zero frequency", "JN58td", "JN48qd", func(p *LinkParams) { p.FrequencyMHz = 0 }},
+		{"zero power", "JN58td", "JN48qd", func(p *LinkParams) { p.TxPowerW = 0 }},
+		{"zero bandwidth", "JN58td", "JN48qd", func(p *LinkParams) { p.BandwidthHz = 0 }},
+		{"negative loss", "JN58td", "JN48qd", func(p *LinkParams) { p.RxLossDB = -1 }},
+		{"NaN noise figure", "JN58td", "JN48qd", func(p *LinkParams) { p.NoiseFigureDB = math.NaN() }},
+		{"NaN TX loss", "JN58td", "JN48qd", func(p *LinkParams) { p.TxLossDB = math.NaN() }},
+		{"infinite RX loss", "JN58td", "JN48qd", func(p *LinkParams) { p.RxLossDB = math.Inf(1) }},
+		{"NaN TX gain", "JN58td", "JN48qd", func(p *LinkParams) { p.TxGainDBi = math.NaN() }},
+		{"infinite RX gain", "JN58td", "JN48qd", func(p *LinkParams) { p.RxGainDBi = math.Inf(-1) }},
+		{"EME without time", "JN58td", "FN31pr", func(p *LinkParams) { p.Mode = EME }},
+		{"negative troposcatter height", "JN58td", "JN48qd", func(p *LinkParams) { p.Mode, p.TxHeightM = Troposcatter, -1 }},
+		{"unknown mode", "JN58td", "JN48qd", func(p *LinkParams) { p.Mode = LinkMode(9) }},
+	}
+	for _, tt := range tests {
+		p := valid
+		tt.modify(&p)
+		if _, err := GetLinkBudget(tt.local, tt.remote, p); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestLinkModeString(t *testing.T) {
+	if FreeSpace.String() != "free space" || EME.String() != "EME" || Troposcatter.String() != "troposcatter" {
+		t.Error("unexpected link mode names")
+	}
+	if got := LinkMode(9).String(); got != "LinkMode(9)" {
+		t.Errorf("got %s want LinkMode(9)", got)
+	}
+}
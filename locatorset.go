@@ -0,0 +1,245 @@
+package maidenhead
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+const locatorSetBinaryVersion = 1 // Version byte written by LocatorSet.MarshalBinary
+
+// errLocatorSetNotInitialized is returned when a LocatorSet was not created with NewLocatorSet.
+var errLocatorSetNotInitialized = errors.New("locator set is not initialized (create it with NewLocatorSet)")
+
+// LocatorSet is a set of fields or squares backed by a bitset, suitable for tracking worked and confirmed
+// grids for awards such as VUCC. Create sets with NewLocatorSet; a nil or zero-value set is empty, and adding to,
+// combining or encoding it returns an error.
+type LocatorSet struct {
+	precision Precision
+	bits      []uint64
+}
+
+// locatorSetJSON is the JSON representation of a LocatorSet
+type locatorSetJSON struct {
+	Precision Precision `json:"precision"`
+	Locators  []string  `json:"locators"`
+}
+
+// NewLocatorSet returns an empty set holding locators at the given precision.
+// Only PrecisionField and PrecisionSquare are supported.
+func NewLocatorSet(precision Precision) (*LocatorSet, error) {
+	if precision != PrecisionField && precision != PrecisionSquare {
+		return nil, fmt.Errorf("unsupported locator set precision: %s", precision)
+	}
+	return &LocatorSet{
+		precision: precision,
+		bits:      make([]uint64, (precision.Count()+63)/64),
+	}, nil
+}
+
+// Precision returns the precision of the locators held by the set.
+func (s *LocatorSet) Precision() Precision {
+	if s == nil {
+		return 0
+	}
+	return s.precision
+}
+
+// Add inserts a locator into the set. Locators finer than the set's precision are truncated,
+// so adding "FN31pr" to a square set adds "FN31". Input is case-insensitive.
+func (s *LocatorSet) Add(locator string) error {
+	idx, err := s.indexOf(locator)
+	if err != nil {
+		return err
+	}
+	s.bits[idx/64] |= 1 << (idx % 64)
+	return nil
+}
+
+// Remove deletes a locator (truncated to the set's precision) from the set.
+func (s *LocatorSet) Remove(locator string) error {
+	idx, err := s.indexOf(locator)
+	if err != nil {
+		return err
+	}
+	s.bits[idx/64] &^= 1 << (idx % 64)
+	return nil
+}
+
+// Contains reports whether the set holds the locator (truncated to the set's precision).
+// Invalid locators and locators coarser than the set's precision are never contained.
+func (s *LocatorSet) Contains(locator string) bool {
+	idx, err := s.indexOf(locator)
+	if err != nil {
+		return false
+	}
+	return s.bits[idx/64]&(1<<(idx%64)) != 0
+}
+
+// Count returns the number of locators in the set.
+func (s *LocatorSet) Count() int {
+	if s == nil {
+		return 0
+	}
+	n := 0
+	for _, w := range s.bits {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// All returns an iterator over the locators in the set in lexical order.
+func (s *LocatorSet) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if s == nil {
+			return
+		}
+		for i, w := range s.bits {
+			for w != 0 {
+				idx := uint32(i*64 + bits.TrailingZeros64(w))
+				if !yield(cellFromIndex(idx, s.precision).locator()) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Union returns a new set holding the locators present in either s or other.
+func (s *LocatorSet) Union(other *LocatorSet) (*LocatorSet, error) {
+	return s.combine(other, func(a, b uint64) uint64 { return a | b })
+}
+
+// Intersect returns a new set holding the locators present in both s and other.
+func (s *LocatorSet) Intersect(other *LocatorSet) (*LocatorSet, error) {
+	return s.combine(other, func(a, b uint64) uint64 { return a & b })
+}
+
+// Difference returns a new set holding the locators present in s but not in other,
+// e.g. worked but not yet confirmed grids.
+func (s *LocatorSet) Difference(other *LocatorSet) (*LocatorSet, error) {
+	return s.combine(other, func(a, b uint64) uint64 { return a &^ b })
+}
+
+// MarshalBinary encodes the set as a version byte, a precision byte and the little-endian bitset.
+func (s *LocatorSet) MarshalBinary() ([]byte, error) {
+	if !s.initialized() {
+		return nil, errLocatorSetNotInitialized
+	}
+	out := make([]byte, 2, 2+len(s.bits)*8)
+	out[0] = locatorSetBinaryVersion
+	out[1] = byte(s.precision)
+	for _, w := range s.bits {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// UnmarshalBinary decodes a set produced by MarshalBinary, replacing the contents of s.
+func (s *LocatorSet) UnmarshalBinary(data []byte) error {
+	if s == nil {
+		return fmt.Errorf("cannot decode into a nil locator set")
+	}
+	if len(data) < 2 {
+		return fmt.Errorf("invalid locator set encoding: too short (%d bytes)", len(data))
+	}
+	if data[0] != locatorSetBinaryVersion {
+		return fmt.Errorf("unsupported locator set encoding version: %d", data[0])
+	}
+	decoded, err := NewLocatorSet(Precision(data[1]))
+	if err != nil {
+		return err
+	}
+	payload := data[2:]
+	if len(payload) != len(decoded.bits)*8 {
+		return fmt.Errorf("invalid locator set encoding: got %d bytes of bitset, want %d", len(payload), len(decoded.bits)*8)
+	}
+	for i := range decoded.bits {
+		decoded.bits[i] = binary.LittleEndian.Uint64(payload[i*8:])
+	}
+	// Reject bits beyond the last valid locator
+	if extra := len(decoded.bits)*64 - decoded.precision.Count(); extra > 0 {
+		if decoded.bits[len(decoded.bits)-1]>>(64-extra) != 0 {
+			return fmt.Errorf("invalid locator set encoding: bits set beyond %d locators", decoded.precision.Count())
+		}
+	}
+	*s = *decoded
+	return nil
+}
+
+// MarshalJSON encodes the set as its precision and a sorted list of canonical locators.
+func (s *LocatorSet) MarshalJSON() ([]byte, error) {
+	if !s.initialized() {
+		return nil, errLocatorSetNotInitialized
+	}
+	locators := make([]string, 0, s.Count())
+	for loc := range s.All() {
+		locators = append(locators, loc)
+	}
+	return json.Marshal(locatorSetJSON{Precision: s.precision, Locators: locators})
+}
+
+// UnmarshalJSON decodes a set produced by MarshalJSON, replacing the contents of s.
+func (s *LocatorSet) UnmarshalJSON(data []byte) error {
+	if s == nil {
+		return fmt.Errorf("cannot decode into a nil locator set")
+	}
+	var raw locatorSetJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	decoded, err := NewLocatorSet(raw.Precision)
+	if err != nil {
+		return err
+	}
+	for _, loc := range raw.Locators {
+		if err = decoded.Add(loc); err != nil {
+			return fmt.Errorf("invalid locator in set: %w", err)
+		}
+	}
+	*s = *decoded
+	return nil
+}
+
+// initialized reports whether the set was created with NewLocatorSet; the zero value has no bitset.
+func (s *LocatorSet) initialized() bool {
+	return s != nil && len(s.bits) > 0
+}
+
+// indexOf returns the dense index of locator once truncated to the set's precision.
+// A nil or zero-value set has no precision, so every locator is rejected rather than indexed.
+func (s *LocatorSet) indexOf(locator string) (uint32, error) {
+	if !s.initialized() {
+		return 0, errLocatorSetNotInitialized
+	}
+	c, err := parseLocator(locator)
+	if err != nil {
+		return 0, err
+	}
+	if c.prec < s.precision {
+		return 0, fmt.Errorf("locator %s is coarser than set precision %s", locator, s.precision)
+	}
+	return c.truncate(s.precision).index(), nil
+}
+
+// combine applies op word by word to s and other, returning the result as a new set.
+func (s *LocatorSet) combine(other *LocatorSet, op func(a, b uint64) uint64) (*LocatorSet, error) {
+	if !s.initialized() || !other.initialized() {
+		return nil, errLocatorSetNotInitialized
+	}
+	if s.precision != other.precision {
+		return nil, fmt.Errorf("locator set precision mismatch")
+	}
+	out, err := NewLocatorSet(s.precision)
+	if err != nil {
+		return nil, err
+	}
+	for i := range out.bits {
+		out.bits[i] = op(s.bits[i], other.bits[i])
+	}
+	return out, nil
+}
@@ -0,0 +1,184 @@
+package maidenhead
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestLocatorSet_AddContainsCount(t *testing.T) {
+	s, err := NewLocatorSet(PrecisionSquare)
+	if err != nil {
+		t.Fatalf("NewLocatorSet error: %v", err)
+	}
+	for _, loc := range []string{"FN31pr", "fn31", "JN58td", "RR99", "AA00"} {
+		if err := s.Add(loc); err != nil {
+			t.Fatalf("Add(%q) error: %v", loc, err)
+		}
+	}
+	if s.Count() != 4 {
+		t.Errorf("Count got %d want 4", s.Count())
+	}
+	for _, loc := range []string{"FN31", "FN31aa", "jn58", "RR99", "AA00"} {
+		if !s.Contains(loc) {
+			t.Errorf("expected set to contain %q", loc)
+		}
+	}
+	for _, loc := range []string{"FN32", "FN", "BAD"} {
+		if s.Contains(loc) {
+			t.Errorf("did not expect set to contain %q", loc)
+		}
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []string{"AA00", "FN31", "JN58", "RR99"}) {
+		t.Errorf("All got %v", got)
+	}
+
+	if err := s.Remove("FN31"); err != nil || s.Contains("FN31") || s.Count() != 3 {
+		t.Errorf("Remove failed: err=%v count=%d", err, s.Count())
+	}
+	if err := s.Add("FN"); err == nil {
+		t.Errorf("expected error adding field to square set")
+	}
+	if _, err := NewLocatorSet(PrecisionSubsquare); err == nil {
+		t.Errorf("expected error for subsquare set")
+	}
+}
+
+func TestLocatorSet_ZeroValue(t *testing.T) {
+	var nilSet *LocatorSet
+	valid, _ := NewLocatorSet(PrecisionField)
+	for name, s := range map[string]*LocatorSet{"zero value": {}, "nil": nilSet} {
+		if err := s.Add("FN31"); err == nil {
+			t.Errorf("%s: expected error from Add", name)
+		}
+		if err := s.Remove("FN31"); err == nil {
+			t.Errorf("%s: expected error from Remove", name)
+		}
+		if s.Contains("FN31") {
+			t.Errorf("%s: Contains should be false", name)
+		}
+		if _, err := s.MarshalBinary(); err == nil {
+			t.Errorf("%s: expected error from MarshalBinary", name)
+		}
+		if _, err := s.MarshalJSON(); err == nil {
+			t.Errorf("%s: expected error from MarshalJSON", name)
+		}
+		if s.Count() != 0 || s.Precision() != 0 {
+			t.Errorf("%s: expected an empty set", name)
+		}
+		for loc := range s.All() {
+			t.Errorf("%s: unexpected locator %s", name, loc)
+		}
+		if _, err := s.Union(valid); err == nil {
+			t.Errorf("%s: expected error from Union", name)
+		}
+		if _, err := valid.Intersect(s); err == nil {
+			t.Errorf("%s: expected error from Intersect", name)
+		}
+	}
+	if err := nilSet.UnmarshalBinary([]byte{locatorSetBinaryVersion, byte(PrecisionField)}); err == nil {
+		t.Error("expected error from UnmarshalBinary into nil set")
+	}
+	if err := nilSet.UnmarshalJSON([]byte(`{"precision":1,"locators":[]}`)); err == nil {
+		t.Error("expected error from UnmarshalJSON into nil set")
+	}
+}
+
+func TestLocatorSet_SetOperations(t *testing.T) {
+	worked, _ := NewLocatorSet(PrecisionSquare)
+	confirmed, _ := NewLocatorSet(PrecisionSquare)
+	for _, loc := range []string{"FN31", "FN42", "EM12", "JN58"} {
+		_ = worked.Add(loc)
+	}
+	for _, loc := range []string{"FN31", "JN58", "IO91"} {
+		_ = confirmed.Add(loc)
+	}
+
+	u, err := worked.Union(confirmed)
+	if err != nil {
+		t.Fatalf("Union error: %v", err)
+	}
+	if got := slices.Collect(u.All()); !slices.Equal(got, []string{"EM12", "FN31", "FN42", "IO91", "JN58"}) {
+		t.Errorf("Union got %v", got)
+	}
+	i, _ := worked.Intersect(confirmed)
+	if got := slices.Collect(i.All()); !slices.Equal(got, []string{"FN31", "JN58"}) {
+		t.Errorf("Intersect got %v", got)
+	}
+	d, _ := worked.Difference(confirmed)
+	if got := slices.Collect(d.All()); !slices.Equal(got, []string{"EM12", "FN42"}) {
+		t.Errorf("Difference got %v", got)
+	}
+
+	fields, _ := NewLocatorSet(PrecisionField)
+	if _, err := worked.Union(fields); err == nil {
+		t.Errorf("expected precision mismatch error")
+	}
+}
+
+func TestLocatorSet_BinaryRoundTrip(t *testing.T) {
+	s, _ := NewLocatorSet(PrecisionSquare)
+	for _, loc := range []string{"AA00", "RR99", "FN31"} {
+		_ = s.Add(loc)
+	}
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary error: %v", err)
+	}
+	var back LocatorSet
+	if err := back.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary error: %v", err)
+	}
+	if back.Precision() != PrecisionSquare || !slices.Equal(slices.Collect(back.All()), slices.Collect(s.All())) {
+		t.Errorf("binary round trip mismatch")
+	}
+
+	if err := back.UnmarshalBinary(data[:10]); err == nil {
+		t.Errorf("expected error for truncated data")
+	}
+	bad := slices.Clone(data)
+	bad[0] = 99
+	if err := back.UnmarshalBinary(bad); err == nil {
+		t.Errorf("expected error for unknown version")
+	}
+	bad = slices.Clone(data)
+	bad[len(bad)-1] = 0xff
+	if err := back.UnmarshalBinary(bad); err == nil {
+		t.Errorf("expected error for bits beyond last locator")
+	}
+}
+
+func TestLocatorSet_JSONRoundTrip(t *testing.T) {
+	s, _ := NewLocatorSet(PrecisionField)
+	_ = s.Add("jn")
+	_ = s.Add("FN31pr")
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if string(data) != `{"precision":2,"locators":["FN","JN"]}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+	var back LocatorSet
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if back.Count() != 2 || !back.Contains("FN") || !back.Contains("JN") {
+		t.Errorf("JSON round trip mismatch: %v", slices.Collect(back.All()))
+	}
+	if err := json.Unmarshal([]byte(`{"precision":4,"locators":["XX00"]}`), &back); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
+
+func TestLocatorSet_MultibyteInput(t *testing.T) {
+	s, _ := NewLocatorSet(PrecisionSquare)
+	for _, in := range []string{"é", "éé", "JNé", "ĴN58"} {
+		if err := s.Add(in); err == nil {
+			t.Errorf("Add(%q): expected error", in)
+		}
+		if s.Contains(in) {
+			t.Errorf("Contains(%q) should be false", in)
+		}
+	}
+}
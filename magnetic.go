@@ -0,0 +1,100 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MagneticBearings holds the true and magnetic bearings between two grid squares for a given date.
+// Magnetic bearings are what a compass reads at the local station when pointed along the path.
+type MagneticBearings struct {
+	LocalGridSquare   string    `json:"localGridSquare"`
+	RemoteGridSquare  string    `json:"remoteGridSquare"`
+	Date              time.Time `json:"date"`
+	Declination       float64   `json:"declination"` // Degrees at the local station, positive east of true north
+	ShortPathBearing  float64   `json:"short_path_bearing"`
+	LongPathBearing   float64   `json:"long_path_bearing"`
+	ShortPathMagnetic float64   `json:"short_path_magnetic"`
+	LongPathMagnetic  float64   `json:"long_path_magnetic"`
+}
+
+// GetMagneticDeclination calculates the magnetic declination at the centre of a locator on a given date using the
+// embedded World Magnetic Model (WMM2025). The result is in degrees, positive when magnetic north lies east of true
+// north, rounded to the nearest 0.1 degree. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - gridSquare: The Maidenhead locator (2, 4 or 6 characters)
+//   - date: The date of interest, which must fall within the model's validity period (2025.0 to 2030.0)
+//
+// Returns:
+//   - float64: The declination in degrees
+//   - error: An error if the locator is invalid or the date is outside the model's validity period
+func GetMagneticDeclination(gridSquare string, date time.Time) (float64, error) {
+	lat, lon, err := locatorCenter(gridSquare)
+	if err != nil {
+		return 0, fmt.Errorf("invalid grid square: %w", err)
+	}
+	declination, err := magneticDeclination(lat, lon, date)
+	if err != nil {
+		return 0, err
+	}
+	return math.Round(declination*10) / 10, nil
+}
+
+// GetMagneticBearings calculates the short and long path bearings between two grid squares and converts them into
+// magnetic bearings using the declination at the local station on the given date. Grid square input is
+// case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead Grid Square of the local station (6 characters)
+//   - remoteGridSquare: The Maidenhead Grid Square of the remote station (6 characters)
+//   - date: The date of interest, which must fall within the model's validity period (2025.0 to 2030.0)
+//
+// Returns:
+//   - *MagneticBearings: The declination and the true and magnetic bearings (0-360°)
+//   - error: An error if either grid square is invalid or the date is outside the model's validity period
+func GetMagneticBearings(localGridSquare, remoteGridSquare string, date time.Time) (*MagneticBearings, error) {
+	spBearing, err := GetShortPathBearing(localGridSquare, remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate short path bearing: %w", err)
+	}
+	lpBearing, err := GetLongPathBearing(localGridSquare, remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate long path bearing: %w", err)
+	}
+	declination, err := GetMagneticDeclination(localGridSquare, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MagneticBearings{
+		LocalGridSquare:   localGridSquare,
+		RemoteGridSquare:  remoteGridSquare,
+		Date:              date,
+		Declination:       declination,
+		ShortPathBearing:  spBearing,
+		LongPathBearing:   lpBearing,
+		ShortPathMagnetic: TrueToMagnetic(spBearing, declination),
+		LongPathMagnetic:  TrueToMagnetic(lpBearing, declination),
+	}, nil
+}
+
+// TrueToMagnetic converts a true bearing into a magnetic bearing for a declination (positive east),
+// normalized to 0-360° and rounded to the nearest 0.1 degree.
+func TrueToMagnetic(bearing, declination float64) float64 {
+	magnetic := math.Mod(bearing-declination, 360)
+	if magnetic < 0 {
+		magnetic += 360
+	}
+	return math.Mod(math.Round(magnetic*10)/10, 360)
+}
+
+// magneticDeclination returns the unrounded declination in degrees at (lat, lon) at time t.
+func magneticDeclination(lat, lon float64, t time.Time) (float64, error) {
+	if _, err := wmmYear(t); err != nil {
+		return 0, err
+	}
+	north, east, _ := magneticField(lat, lon, t)
+	return toDegrees(math.Atan2(east, north)), nil
+}
@@ -0,0 +1,96 @@
+package maidenhead
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMagneticField(t *testing.T) {
+	// Field strength is roughly 25000 nT near the South Atlantic Anomaly and up to 66000 nT near the magnetic poles
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	north, east, down := magneticField(80, 0, at)
+	if total := math.Sqrt(north*north + east*east + down*down); total < 50000 || total > 60000 {
+		t.Errorf("total intensity at 80N 0E got %.0f nT", total)
+	}
+	if down <= 0 {
+		t.Errorf("field should point down in the northern hemisphere, got %.0f nT", down)
+	}
+	if _, _, down := magneticField(-80, 240, at); down >= 0 {
+		t.Errorf("field should point up in the southern hemisphere, got %.0f nT", down)
+	}
+}
+
+func TestGetMagneticDeclination(t *testing.T) {
+	at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		grid string
+		want float64
+	}{
+		{"DN70", 7.7},   // Boulder, Colorado
+		{"IO91wm", 0.9}, // London
+		{"CN87", 15.3},  // Seattle
+		{"FN84", -16.6}, // Nova Scotia
+		{"JF96", -26.7}, // Cape Town
+		{"QF56", 12.9},  // Sydney
+	}
+	for _, tt := range tests {
+		t.Run(tt.grid, func(t *testing.T) {
+			got, err := GetMagneticDeclination(tt.grid, at)
+			if err != nil {
+				t.Fatalf("GetMagneticDeclination error: %v", err)
+			}
+			if !almostEqual(got, tt.want, 1.5) {
+				t.Errorf("got %.1f want ~%.1f", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := GetMagneticDeclination("BAD", at); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+	if _, err := GetMagneticDeclination("DN70", time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("expected error for date outside the model validity period")
+	}
+}
+
+func TestTrueToMagnetic(t *testing.T) {
+	tests := []struct {
+		bearing, declination, want float64
+	}{
+		{90, 10, 80},
+		{5, 10, 355},
+		{355, -10, 5},
+		{0, 0, 0},
+		{359.96, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := TrueToMagnetic(tt.bearing, tt.declination); got != tt.want {
+			t.Errorf("TrueToMagnetic(%v, %v) got %v want %v", tt.bearing, tt.declination, got, tt.want)
+		}
+	}
+}
+
+func TestGetMagneticBearings(t *testing.T) {
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := GetMagneticBearings("FN84aa", "JO65ha", at)
+	if err != nil {
+		t.Fatalf("GetMagneticBearings error: %v", err)
+	}
+	sp, _ := GetShortPathBearing("FN84aa", "JO65ha")
+	lp, _ := GetLongPathBearing("FN84aa", "JO65ha")
+	if got.ShortPathBearing != sp || got.LongPathBearing != lp {
+		t.Errorf("true bearings got %.1f/%.1f want %.1f/%.1f", got.ShortPathBearing, got.LongPathBearing, sp, lp)
+	}
+	// West declination means the compass reads more than the true bearing
+	if got.Declination >= 0 {
+		t.Errorf("expected west declination in Nova Scotia, got %.1f", got.Declination)
+	}
+	if got.ShortPathMagnetic != TrueToMagnetic(sp, got.Declination) || got.LongPathMagnetic != TrueToMagnetic(lp, got.Declination) {
+		t.Errorf("magnetic bearings inconsistent: %+v", got)
+	}
+
+	if _, err := GetMagneticBearings("FN84", "JO65ha", at); err == nil {
+		t.Errorf("expected error for 4-character locator")
+	}
+}
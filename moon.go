@@ -0,0 +1,210 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	earthEquatorialRadKm = 6378.14         // Earth equatorial radius in kilometers, the unit of the lunar orbit elements
+	moonStep             = time.Minute     // Sampling interval used when searching for common Moon windows
+	julianDay2000Jan0    = 2451543.5       // Julian day at 1999-12-31T00:00:00Z, the epoch of the lunar orbit elements
+	siderealRate         = 360.98564736629 // Degrees of sidereal rotation per day
+)
+
+// MoonPosition is the Moon's topocentric position as seen from a locator.
+type MoonPosition struct {
+	GridSquare string    `json:"gridSquare"`
+	Time       time.Time `json:"time"`
+	Azimuth    float64   `json:"azimuth"`   // Degrees clockwise from true north
+	Elevation  float64   `json:"elevation"` // Degrees above the horizon, corrected for parallax
+	DistanceKm float64   `json:"distance_km"`
+}
+
+// MoonWindow is a period during which the Moon is above the minimum elevation at both stations.
+type MoonWindow struct {
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+	// EarthMoonDistanceKm is the geocentric Moon distance at the middle of the window, for estimating path loss.
+	EarthMoonDistanceKm float64 `json:"earth_moon_distance_km"`
+}
+
+// GetMoonPosition calculates the Moon's azimuth, elevation and distance from the centre of a locator at a given time.
+// Accuracy is a few tenths of a degree, which is ample for pointing EME arrays. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - gridSquare: The Maidenhead locator of the station (2, 4 or 6 characters)
+//   - at: The time of interest
+//
+// Returns:
+//   - *MoonPosition: The Moon's azimuth, elevation and distance from the station
+//   - error: An error if the locator is invalid
+func GetMoonPosition(gridSquare string, at time.Time) (*MoonPosition, error) {
+	lat, lon, err := locatorCenter(gridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grid square: %w", err)
+	}
+
+	ra, dec, dist := moonCoordinates(at)
+	elevation, azimuth, topoDist := moonTopocentric(lat, lon, at, ra, dec, dist)
+	return &MoonPosition{
+		GridSquare: gridSquare,
+		Time:       at.UTC(),
+		Azimuth:    math.Round(azimuth*10) / 10,
+		Elevation:  math.Round(elevation*10) / 10,
+		DistanceKm: math.Round(topoDist),
+	}, nil
+}
+
+// GetMoonWindows finds the periods when the Moon is at or above minElevation for both stations over a range of UTC
+// dates, i.e. the common windows for an EME contact. Windows are found to the nearest minute; a window that
+// continues past the end of the range is cut off there. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead locator of the local station (2, 4 or 6 characters)
+//   - remoteGridSquare: The Maidenhead locator of the remote station (2, 4 or 6 characters)
+//   - from: The first UTC date to search
+//   - to: The last UTC date to search (inclusive)
+//   - minElevation: The lowest usable Moon elevation in degrees at each station
+//
+// Returns:
+//   - []MoonWindow: The common windows in chronological order
+//   - error: An error if either locator or the date range is invalid
+func GetMoonWindows(localGridSquare, remoteGridSquare string, from, to time.Time, minElevation float64) ([]MoonWindow, error) {
+	localLat, localLon, err := locatorCenter(localGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local grid square: %w", err)
+	}
+	remoteLat, remoteLon, err := locatorCenter(remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote grid square: %w", err)
+	}
+
+	start := utcDay(from)
+	end := utcDay(to).Add(24 * time.Hour)
+	if !end.After(start) {
+		return nil, fmt.Errorf("invalid date range: %s to %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+
+	both := func(t time.Time) bool {
+		ra, dec, dist := moonCoordinates(t)
+		if elev, _, _ := moonTopocentric(localLat, localLon, t, ra, dec, dist); elev < minElevation {
+			return false
+		}
+		elev, _, _ := moonTopocentric(remoteLat, remoteLon, t, ra, dec, dist)
+		return elev >= minElevation
+	}
+
+	var windows []MoonWindow
+	for _, span := range findWindows(start, end, moonStep, both) {
+		duration := span.end.Sub(span.start)
+		_, _, dist := moonCoordinates(span.start.Add(duration / 2))
+		windows = append(windows, MoonWindow{
+			Start:               span.start,
+			End:                 span.end,
+			Duration:            duration,
+			EarthMoonDistanceKm: math.Round(dist),
+		})
+	}
+	return windows, nil
+}
+
+// moonCoordinates returns the Moon's geocentric right ascension and declination (radians) and distance (km) at t,
+// using the low-precision orbital elements and main perturbation terms from Paul Schlyter's
+// "How to compute planetary positions".
+func moonCoordinates(t time.Time) (float64, float64, float64) {
+	d := julianDay(t) - julianDay2000Jan0
+
+	// Orbital elements of the Moon (degrees, Earth radii) and of the Sun
+	node := 125.1228 - 0.0529538083*d
+	incl := 5.1454
+	perigee := 318.0634 + 0.1643573223*d
+	semiMajor := 60.2666
+	ecc := 0.054900
+	meanAnom := 115.3654 + 13.0649929509*d
+	sunPerigee := 282.9404 + 4.70935e-5*d
+	sunAnom := 356.0470 + 0.9856002585*d
+
+	// Solve Kepler's equation for the eccentric anomaly
+	m := toRadians(meanAnom)
+	e := m + ecc*math.Sin(m)*(1+ecc*math.Cos(m))
+	for i := 0; i < 5; i++ {
+		e -= (e - ecc*math.Sin(e) - m) / (1 - ecc*math.Cos(e))
+	}
+	xv := semiMajor * (math.Cos(e) - ecc)
+	yv := semiMajor * math.Sqrt(1-ecc*ecc) * math.Sin(e)
+	trueAnom := math.Atan2(yv, xv)
+	r := math.Hypot(xv, yv)
+
+	// Position in ecliptic coordinates
+	n := toRadians(node)
+	vw := trueAnom + toRadians(perigee)
+	i := toRadians(incl)
+	xh := r * (math.Cos(n)*math.Cos(vw) - math.Sin(n)*math.Sin(vw)*math.Cos(i))
+	yh := r * (math.Sin(n)*math.Cos(vw) + math.Cos(n)*math.Sin(vw)*math.Cos(i))
+	zh := r * math.Sin(vw) * math.Sin(i)
+	eclLon := toDegrees(math.Atan2(yh, xh))
+	eclLat := toDegrees(math.Atan2(zh, math.Hypot(xh, yh)))
+
+	// Main perturbations in longitude, latitude and distance
+	ms := toRadians(sunAnom)
+	mm := m
+	lm := toRadians(meanAnom + perigee + node)
+	dd := lm - toRadians(sunAnom+sunPerigee) // Mean elongation
+	f := lm - n                              // Argument of latitude
+	eclLon += -1.274*math.Sin(mm-2*dd) + 0.658*math.Sin(2*dd) - 0.186*math.Sin(ms) -
+		0.059*math.Sin(2*mm-2*dd) - 0.057*math.Sin(mm-2*dd+ms) + 0.053*math.Sin(mm+2*dd) +
+		0.046*math.Sin(2*dd-ms) + 0.041*math.Sin(mm-ms) - 0.035*math.Sin(dd) -
+		0.031*math.Sin(mm+ms) - 0.015*math.Sin(2*f-2*dd) + 0.011*math.Sin(mm-4*dd)
+	eclLat += -0.173*math.Sin(f-2*dd) - 0.055*math.Sin(mm-f-2*dd) - 0.046*math.Sin(mm+f-2*dd) +
+		0.033*math.Sin(f+2*dd) + 0.017*math.Sin(2*mm+f)
+	r += -0.58*math.Cos(mm-2*dd) - 0.46*math.Cos(2*dd)
+
+	// Convert ecliptic to equatorial coordinates
+	lonRad, latRad := toRadians(eclLon), toRadians(eclLat)
+	obliq := toRadians(23.4393 - 3.563e-7*d)
+	x := math.Cos(lonRad) * math.Cos(latRad)
+	y := math.Sin(lonRad)*math.Cos(latRad)*math.Cos(obliq) - math.Sin(latRad)*math.Sin(obliq)
+	z := math.Sin(lonRad)*math.Cos(latRad)*math.Sin(obliq) + math.Sin(latRad)*math.Cos(obliq)
+	ra := math.Atan2(y, x)
+	dec := math.Atan2(z, math.Hypot(x, y))
+
+	return ra, dec, r * earthEquatorialRadKm
+}
+
+// moonTopocentric converts the Moon's geocentric coordinates into elevation and azimuth (degrees) and distance (km)
+// as seen from a station at (lat, lon), correcting the elevation for parallax.
+func moonTopocentric(lat, lon float64, t time.Time, ra, dec, distKm float64) (float64, float64, float64) {
+	hourAngle := toRadians(greenwichSiderealTime(t)+lon) - ra
+	elevation, azimuth := equatorialToHorizontal(toRadians(lat), hourAngle, dec)
+
+	// Parallax: the Moon is close enough that an observer on the surface sees it lower than the geocentre does
+	elevRad := toRadians(elevation)
+	parallax := math.Asin(earthEquatorialRadKm / distKm * math.Cos(elevRad))
+	topoDist := math.Sqrt(distKm*distKm + earthRad*earthRad - 2*distKm*earthRad*math.Sin(elevRad))
+
+	return elevation - toDegrees(parallax), azimuth, topoDist
+}
+
+// equatorialToHorizontal converts an hour angle and declination (radians) at latitude lat (radians) into
+// elevation and azimuth in degrees, azimuth measured clockwise from true north.
+func equatorialToHorizontal(lat, hourAngle, dec float64) (float64, float64) {
+	sinElev := math.Sin(lat)*math.Sin(dec) + math.Cos(lat)*math.Cos(dec)*math.Cos(hourAngle)
+	elevation := math.Asin(math.Max(-1, math.Min(1, sinElev)))
+	azimuth := math.Atan2(
+		math.Sin(hourAngle),
+		math.Cos(hourAngle)*math.Sin(lat)-math.Tan(dec)*math.Cos(lat),
+	) + math.Pi
+	return toDegrees(elevation), math.Mod(toDegrees(azimuth), 360)
+}
+
+// greenwichSiderealTime returns Greenwich mean sidereal time at t in degrees.
+func greenwichSiderealTime(t time.Time) float64 {
+	gmst := math.Mod(280.46061837+siderealRate*(julianDay(t)-julianJ2000), 360)
+	if gmst < 0 {
+		gmst += 360
+	}
+	return gmst
+}
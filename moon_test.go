@@ -0,0 +1,84 @@
+package maidenhead
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMoonCoordinates_Meeus(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 47.a: 1992 April 12, 0h TD
+	at := time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC)
+	ra, dec, dist := moonCoordinates(at)
+	raDeg := math.Mod(toDegrees(ra)+360, 360)
+	if !almostEqual(raDeg, 134.688470, 0.3) {
+		t.Errorf("right ascension got %.4f want ~134.6885", raDeg)
+	}
+	if !almostEqual(toDegrees(dec), 13.768368, 0.3) {
+		t.Errorf("declination got %.4f want ~13.7684", toDegrees(dec))
+	}
+	if !almostEqual(dist, 368409.7, 1000) {
+		t.Errorf("distance got %.1f want ~368409.7", dist)
+	}
+}
+
+func TestGetMoonPosition(t *testing.T) {
+	at := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+	pos, err := GetMoonPosition("JO65", at)
+	if err != nil {
+		t.Fatalf("GetMoonPosition error: %v", err)
+	}
+	if pos.Azimuth < 0 || pos.Azimuth >= 360 || pos.Elevation < -90 || pos.Elevation > 90 {
+		t.Errorf("position out of range: %+v", pos)
+	}
+	if pos.DistanceKm < 350000 || pos.DistanceKm > 410000 {
+		t.Errorf("distance out of range: %+v", pos)
+	}
+
+	// Directly below the Moon the elevation is ~90° and the distance is one Earth radius less than geocentric
+	ra, dec, dist := moonCoordinates(at)
+	subLat := toDegrees(dec)
+	subLon := normalizeLongitude(toDegrees(ra) - greenwichSiderealTime(at))
+	elev, _, topo := moonTopocentric(subLat, subLon, at, ra, dec, dist)
+	if !almostEqual(elev, 90, 0.01) || !almostEqual(topo, dist-earthRad, 1) {
+		t.Errorf("sub-lunar point got elevation %.3f distance %.1f, want 90 and %.1f", elev, topo, dist-earthRad)
+	}
+
+	if _, err := GetMoonPosition("BAD", at); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
+
+func TestGetMoonWindows(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+	windows, err := GetMoonWindows("JO65", "FN31pr", from, to, 10)
+	if err != nil {
+		t.Fatalf("GetMoonWindows error: %v", err)
+	}
+	if len(windows) < 2 {
+		t.Fatalf("expected a common window on most days, got %d", len(windows))
+	}
+	for _, w := range windows {
+		if w.Duration != w.End.Sub(w.Start) || w.Duration <= 0 {
+			t.Errorf("bad window duration: %+v", w)
+		}
+		if w.EarthMoonDistanceKm < 350000 || w.EarthMoonDistanceKm > 410000 {
+			t.Errorf("bad Earth-Moon distance: %+v", w)
+		}
+		mid := w.Start.Add(w.Duration / 2)
+		for _, grid := range []string{"JO65", "FN31pr"} {
+			pos, _ := GetMoonPosition(grid, mid)
+			if pos.Elevation < 10 {
+				t.Errorf("window %+v: Moon at %.1f° from %s, want >= 10", w, pos.Elevation, grid)
+			}
+		}
+	}
+
+	if _, err := GetMoonWindows("JO65", "BAD", from, to, 10); err == nil {
+		t.Errorf("expected error for invalid remote locator")
+	}
+	if _, err := GetMoonWindows("JO65", "FN31", to, from, 10); err == nil {
+		t.Errorf("expected error for reversed date range")
+	}
+}
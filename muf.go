@@ -0,0 +1,137 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	controlPointOffsetKm = 2000.0 // Distance of the control points from each end of paths longer than 4000 km
+	fotFactor            = 0.85   // Ratio of the optimum working frequency to the MUF
+)
+
+// ControlPoint is a point along a path at which the ionosphere is evaluated.
+type ControlPoint struct {
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	DistanceKm  float64 `json:"distance_km"`
+	SolarZenith float64 `json:"solar_zenith"` // Degrees; above 90 the Sun is below the horizon
+	FoF2        float64 `json:"fof2"`         // Estimated F2 critical frequency in MHz
+}
+
+// HFPrediction is a rough MUF/LUF estimate for an HF path at an instant.
+type HFPrediction struct {
+	LocalGridSquare  string         `json:"localGridSquare"`
+	RemoteGridSquare string         `json:"remoteGridSquare"`
+	PathType         PathType       `json:"path_type"`
+	Time             time.Time      `json:"time"`
+	SunspotNumber    float64        `json:"sunspot_number"`
+	DistanceKm       float64        `json:"distance_km"`
+	Hops             int            `json:"hops"`
+	ControlPoints    []ControlPoint `json:"control_points"`
+	MUF              float64        `json:"muf"` // Maximum usable frequency in MHz
+	FOT              float64        `json:"fot"` // Optimum working frequency in MHz
+	LUF              float64        `json:"luf"` // Lowest usable frequency in MHz; above the MUF the path is closed
+}
+
+// IsOpen reports whether the band's representative frequency lies between the LUF and the MUF.
+func (p *HFPrediction) IsOpen(band Band) bool {
+	f := band.FrequencyMHz()
+	return band.Valid() && f >= p.LUF && f <= p.MUF
+}
+
+// OpenBands returns the bands predicted to be open, lowest frequency first.
+func (p *HFPrediction) OpenBands() []Band {
+	var open []Band
+	for b := Band160m; b.Valid(); b++ {
+		if p.IsOpen(b) {
+			open = append(open, b)
+		}
+	}
+	return open
+}
+
+// GetHFPrediction estimates the maximum and lowest usable frequencies for the short or long path between two locators
+// at a given time and smoothed sunspot number. Following the CCIR control point method, the F2 layer is evaluated at
+// the path midpoint, or 2000 km from each end for paths longer than 4000 km, with foF2 driven by the solar zenith
+// angle and sunspot number. This is a simple empirical model meant to answer "is 15 m likely open to that grid now",
+// not a replacement for VOACAP. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead locator of the local station (2, 4 or 6 characters)
+//   - remoteGridSquare: The Maidenhead locator of the remote station (2, 4 or 6 characters)
+//   - pathType: ShortPath or LongPath
+//   - at: The time of interest
+//   - sunspotNumber: The smoothed sunspot number (0-300)
+//
+// Returns:
+//   - *HFPrediction: The control points, MUF, FOT and LUF in MHz
+//   - error: An error if either locator, the path type or the sunspot number is invalid
+func GetHFPrediction(localGridSquare, remoteGridSquare string, pathType PathType, at time.Time, sunspotNumber float64) (*HFPrediction, error) {
+	if sunspotNumber < 0 || sunspotNumber > 300 || math.IsNaN(sunspotNumber) {
+		return nil, fmt.Errorf("invalid sunspot number: %v (must be 0-300)", sunspotNumber)
+	}
+	path, err := newGreatCirclePath(localGridSquare, remoteGridSquare, pathType)
+	if err != nil {
+		return nil, err
+	}
+
+	hops, _ := hopGeometry(path.distanceKm, f2HeightKm)
+	hopKm := path.distanceKm / float64(hops)
+	prediction := &HFPrediction{
+		LocalGridSquare:  localGridSquare,
+		RemoteGridSquare: remoteGridSquare,
+		PathType:         pathType,
+		Time:             at.UTC(),
+		SunspotNumber:    sunspotNumber,
+		DistanceKm:       math.Ceil(path.distanceKm),
+		Hops:             hops,
+	}
+
+	offsets := []float64{path.distanceKm / 2}
+	if path.distanceKm > 2*controlPointOffsetKm {
+		offsets = []float64{controlPointOffsetKm, path.distanceKm - controlPointOffsetKm}
+	}
+	muf, maxCosZenith := math.Inf(1), 0.0
+	for _, d := range offsets {
+		lat, lon := path.pointAt(d)
+		elevation, _ := sunPosition(lat, lon, at)
+		zenith := 90 - elevation
+		foF2 := estimateFoF2(zenith, sunspotNumber)
+		prediction.ControlPoints = append(prediction.ControlPoints, ControlPoint{
+			Latitude:    lat,
+			Longitude:   lon,
+			DistanceKm:  math.Round(d),
+			SolarZenith: math.Round(zenith*10) / 10,
+			FoF2:        math.Round(foF2*10) / 10,
+		})
+		muf = math.Min(muf, foF2*obliquityFactor(hopKm))
+		maxCosZenith = math.Max(maxCosZenith, math.Cos(toRadians(zenith)))
+	}
+
+	// D-layer absorption sets the LUF; it rises with solar illumination, solar activity and the number of hops
+	luf := math.Max(1.5, 3.5*(1+0.008*sunspotNumber)*math.Pow(maxCosZenith, 0.75)*math.Sqrt(float64(hops)))
+
+	prediction.MUF = math.Round(muf*10) / 10
+	prediction.FOT = math.Round(muf*fotFactor*10) / 10
+	prediction.LUF = math.Round(luf*10) / 10
+	return prediction, nil
+}
+
+// estimateFoF2 returns an F2 critical frequency in MHz for a solar zenith angle in degrees and a sunspot number.
+// Daytime values follow the Sun's elevation; at night the layer decays to a floor that also rises with solar activity.
+func estimateFoF2(zenith, sunspotNumber float64) float64 {
+	night := 2.5 + 0.015*sunspotNumber
+	cosZenith := math.Cos(toRadians(math.Min(zenith, 90)))
+	day := (5.5 + 0.045*sunspotNumber) * math.Pow(cosZenith, 0.4)
+	return math.Max(night, day)
+}
+
+// obliquityFactor returns the ratio of the MUF to foF2 for a hop of hopKm via the F2 layer, using the secant law
+// with the Earth's curvature: a 3000 km hop gives a factor of about 3.
+func obliquityFactor(hopKm float64) float64 {
+	elevation := toRadians(math.Max(takeoffAngle(hopKm, f2HeightKm), 0))
+	sinIncidence := earthRad * math.Cos(elevation) / (earthRad + f2HeightKm)
+	return 1 / math.Sqrt(1-sinIncidence*sinIncidence)
+}
@@ -0,0 +1,87 @@
+package maidenhead
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateFoF2(t *testing.T) {
+	// foF2 rises with solar elevation and sunspot number and never drops below the night floor
+	if estimateFoF2(30, 100) <= estimateFoF2(70, 100) {
+		t.Errorf("foF2 should be higher with the Sun higher")
+	}
+	if estimateFoF2(30, 150) <= estimateFoF2(30, 10) {
+		t.Errorf("foF2 should be higher at solar maximum")
+	}
+	if got := estimateFoF2(120, 0); got != 2.5 {
+		t.Errorf("night foF2 got %.2f want 2.5", got)
+	}
+}
+
+func TestObliquityFactor(t *testing.T) {
+	if got := obliquityFactor(0); !almostEqual(got, 1, 1e-9) {
+		t.Errorf("vertical incidence got %.3f want 1", got)
+	}
+	if got := obliquityFactor(3000); got < 2.8 || got > 3.5 {
+		t.Errorf("3000 km hop got %.3f want ~3", got)
+	}
+	if obliquityFactor(1000) >= obliquityFactor(2000) {
+		t.Errorf("factor should grow with hop length")
+	}
+}
+
+func TestGetHFPrediction(t *testing.T) {
+	noon := time.Date(2025, 3, 15, 15, 0, 0, 0, time.UTC)
+	night := time.Date(2025, 3, 15, 3, 0, 0, 0, time.UTC)
+
+	day, err := GetHFPrediction("JO65", "FN31", ShortPath, noon, 150)
+	if err != nil {
+		t.Fatalf("GetHFPrediction error: %v", err)
+	}
+	if day.Hops != 2 || len(day.ControlPoints) != 2 {
+		t.Errorf("short path should use two control points and two hops, got %d and %d", len(day.ControlPoints), day.Hops)
+	}
+	if day.ControlPoints[0].DistanceKm != controlPointOffsetKm {
+		t.Errorf("first control point at %.0f km want %.0f", day.ControlPoints[0].DistanceKm, controlPointOffsetKm)
+	}
+	if !(day.LUF < day.FOT && day.FOT < day.MUF) {
+		t.Errorf("expected LUF < FOT < MUF, got %.1f %.1f %.1f", day.LUF, day.FOT, day.MUF)
+	}
+	if !day.IsOpen(Band15m) || day.IsOpen(Band160m) {
+		t.Errorf("expected 15m open and 160m closed at solar maximum midday, open bands %v", day.OpenBands())
+	}
+
+	dark, err := GetHFPrediction("JO65", "FN31", ShortPath, night, 150)
+	if err != nil {
+		t.Fatalf("GetHFPrediction error: %v", err)
+	}
+	if dark.MUF >= day.MUF || dark.LUF >= day.LUF {
+		t.Errorf("night MUF/LUF %.1f/%.1f should be below day %.1f/%.1f", dark.MUF, dark.LUF, day.MUF, day.LUF)
+	}
+	if dark.IsOpen(Band15m) || !dark.IsOpen(Band40m) {
+		t.Errorf("expected 15m closed and 40m open at night, open bands %v", dark.OpenBands())
+	}
+
+	quiet, err := GetHFPrediction("JO65", "FN31", ShortPath, noon, 10)
+	if err != nil {
+		t.Fatalf("GetHFPrediction error: %v", err)
+	}
+	if quiet.MUF >= day.MUF {
+		t.Errorf("solar minimum MUF %.1f should be below solar maximum %.1f", quiet.MUF, day.MUF)
+	}
+
+	short, err := GetHFPrediction("JO65", "JO75", ShortPath, noon, 100)
+	if err != nil {
+		t.Fatalf("GetHFPrediction error: %v", err)
+	}
+	if len(short.ControlPoints) != 1 || short.Hops != 1 || short.MUF >= day.MUF {
+		t.Errorf("short path should have one control point and a lower MUF: %+v", short)
+	}
+
+	if _, err := GetHFPrediction("JO65", "FN31", ShortPath, noon, -1); err == nil {
+		t.Errorf("expected error for negative sunspot number")
+	}
+	if _, err := GetHFPrediction("JO65", "BAD", ShortPath, noon, 100); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
@@ -0,0 +1,103 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+)
+
+// PathType selects the short or long great-circle path between two stations.
+type PathType int
+
+const (
+	ShortPath PathType = iota // The shorter great-circle arc
+	LongPath                  // The complementary arc the other way around the globe
+)
+
+// String returns a human-readable name for the path type.
+func (p PathType) String() string {
+	switch p {
+	case ShortPath:
+		return "short path"
+	case LongPath:
+		return "long path"
+	default:
+		return fmt.Sprintf("PathType(%d)", int(p))
+	}
+}
+
+// greatCirclePath describes a path from a start point along an initial bearing for a given distance.
+type greatCirclePath struct {
+	startLat   float64
+	startLon   float64
+	bearing    float64 // Initial bearing in degrees, unrounded
+	distanceKm float64 // Length of the path, unrounded
+}
+
+// newGreatCirclePath resolves two locators into the short or long path between their centres.
+func newGreatCirclePath(localGridSquare, remoteGridSquare string, pathType PathType) (*greatCirclePath, error) {
+	localLat, localLon, err := locatorCenter(localGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local grid square: %w", err)
+	}
+	remoteLat, remoteLon, err := locatorCenter(remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote grid square: %w", err)
+	}
+
+	p := &greatCirclePath{
+		startLat:   localLat,
+		startLon:   localLon,
+		bearing:    rawBearing(localLat, localLon, remoteLat, remoteLon),
+		distanceKm: haversineKm(localLat, localLon, remoteLat, remoteLon),
+	}
+	switch pathType {
+	case ShortPath:
+	case LongPath:
+		p.bearing = math.Mod(p.bearing+180, 360)
+		p.distanceKm = 2*math.Pi*earthRad - p.distanceKm
+	default:
+		return nil, fmt.Errorf("unsupported path type: %s", pathType)
+	}
+	return p, nil
+}
+
+// pointAt returns the latitude and longitude of the point distanceKm along the path.
+func (p *greatCirclePath) pointAt(distanceKm float64) (float64, float64) {
+	return destinationPoint(p.startLat, p.startLon, p.bearing, distanceKm)
+}
+
+// samples returns n+1 evenly spaced points along the path, including both ends, as (lat, lon, distance from start).
+func (p *greatCirclePath) samples(n int) [][3]float64 {
+	points := make([][3]float64, 0, n+1)
+	for i := 0; i <= n; i++ {
+		d := p.distanceKm * float64(i) / float64(n)
+		lat, lon := p.pointAt(d)
+		points = append(points, [3]float64{lat, lon, d})
+	}
+	return points
+}
+
+// destinationPoint returns the point reached by travelling distanceKm from (lat, lon) along the initial bearing,
+// following a great circle. Longitude is normalized into [-180, 180).
+func destinationPoint(lat, lon, bearing, distanceKm float64) (float64, float64) {
+	latRad := toRadians(lat)
+	lonRad := toRadians(lon)
+	brgRad := toRadians(bearing)
+	angular := distanceKm / earthRad
+
+	lat2 := math.Asin(math.Sin(latRad)*math.Cos(angular) + math.Cos(latRad)*math.Sin(angular)*math.Cos(brgRad))
+	lon2 := lonRad + math.Atan2(
+		math.Sin(brgRad)*math.Sin(angular)*math.Cos(latRad),
+		math.Cos(angular)-math.Sin(latRad)*math.Sin(lat2),
+	)
+	return toDegrees(lat2), normalizeLongitude(toDegrees(lon2))
+}
+
+// normalizeLongitude wraps a longitude into [-180, 180).
+func normalizeLongitude(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
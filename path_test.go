@@ -0,0 +1,37 @@
+package maidenhead
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGreatCirclePath_EndsAtRemote(t *testing.T) {
+	remoteLat, remoteLon, _ := locatorCenter("FN31pr")
+	for _, pt := range []PathType{ShortPath, LongPath} {
+		p, err := newGreatCirclePath("JN58td", "FN31pr", pt)
+		if err != nil {
+			t.Fatalf("newGreatCirclePath(%s) error: %v", pt, err)
+		}
+		lat, lon := p.pointAt(p.distanceKm)
+		if !almostEqual(lat, remoteLat, 1e-6) || !almostEqual(lon, remoteLon, 1e-6) {
+			t.Errorf("%s end got (%.6f,%.6f) want (%.6f,%.6f)", pt, lat, lon, remoteLat, remoteLon)
+		}
+	}
+
+	short, _ := newGreatCirclePath("JN58td", "FN31pr", ShortPath)
+	long, _ := newGreatCirclePath("JN58td", "FN31pr", LongPath)
+	if !almostEqual(short.distanceKm+long.distanceKm, 2*math.Pi*earthRad, 1e-6) {
+		t.Errorf("short+long distance got %.3f", short.distanceKm+long.distanceKm)
+	}
+	if _, err := newGreatCirclePath("JN58td", "FN31pr", PathType(7)); err == nil {
+		t.Errorf("expected error for unknown path type")
+	}
+}
+
+func TestDestinationPoint_Antimeridian(t *testing.T) {
+	// Due east along the equator across 180°
+	lat, lon := destinationPoint(0, 179, 90, 2*math.Pi*earthRad/360*2)
+	if !almostEqual(lat, 0, 1e-9) || !almostEqual(lon, -179, 1e-9) {
+		t.Errorf("got (%.6f,%.6f) want (0,-179)", lat, lon)
+	}
+}
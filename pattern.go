@@ -0,0 +1,268 @@
+package maidenhead
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Pattern is a compiled set of locator patterns used to test whether a locator lies in an area of interest,
+// for example when filtering DX spots. Create patterns with CompilePattern.
+type Pattern struct {
+	source string
+	terms  []patternTerm
+}
+
+// patternTerm is one comma-separated alternative of a Pattern. It is either a per-position match
+// (masks) or a rectangular range of cells (from/to).
+type patternTerm struct {
+	masks   []uint32 // Allowed values for each locator position, bit n set if value n is allowed
+	isRange bool
+	from    cell // South-west corner of a range term
+	to      cell // North-east corner of a range term
+}
+
+// CompilePattern parses a locator pattern into a Pattern. Patterns describe an area of the grid: a locator matches
+// if it lies inside that area, so "FN31" matches FN31, FN31pr and so on, but not the coarser field FN.
+// Input is case-insensitive. The supported syntax is:
+//   - Literal characters, e.g. "JN58"
+//   - ? matches any valid character at that position, e.g. "FN3?"
+//   - [...] matches a class of characters such as "IO9[0-3]" or "JO[0246]"; a leading ! or ^ negates the class
+//   - A trailing * matches anything further, e.g. "JN*"; a pattern of just "*" matches every locator
+//   - Two locators of the same precision joined by "-" match the rectangle between them, e.g. "FN20-FN39" or "IO-JO".
+//     If the first locator lies east of the second, the range wraps across the antimeridian
+//   - Several patterns separated by commas match if any of them matches, e.g. "JO*,IO9?"
+//
+// Parameters:
+//   - pattern: The pattern to compile
+//
+// Returns:
+//   - *Pattern: The compiled pattern
+//   - error: An error describing the first syntax problem found
+func CompilePattern(pattern string) (*Pattern, error) {
+	p := &Pattern{source: pattern}
+	for _, raw := range strings.Split(pattern, ",") {
+		term := strings.TrimSpace(raw)
+		if term == "" {
+			return nil, fmt.Errorf("invalid pattern %q: empty alternative", pattern)
+		}
+
+		var (
+			compiled patternTerm
+			err      error
+		)
+		if strings.Contains(term, "-") && !strings.Contains(term, "[") {
+			compiled, err = compileRangeTerm(term)
+		} else {
+			compiled, err = compileMaskTerm(term)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		p.terms = append(p.terms, compiled)
+	}
+	return p, nil
+}
+
+// String returns the source text the pattern was compiled from.
+func (p *Pattern) String() string {
+	return p.source
+}
+
+// Match reports whether the locator lies inside the area described by the pattern.
+// Invalid locators never match.
+func (p *Pattern) Match(locator string) bool {
+	c, err := parseLocator(locator)
+	if err != nil {
+		return false
+	}
+	normalized := c.locator()
+	for _, term := range p.terms {
+		if term.match(c, normalized) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether the parsed locator c (with canonical string normalized) satisfies the term.
+func (t patternTerm) match(c cell, normalized string) bool {
+	if t.isRange {
+		if c.prec < t.from.prec {
+			return false
+		}
+		tc := c.truncate(t.from.prec)
+		if tc.row < t.from.row || tc.row > t.to.row {
+			return false
+		}
+		if t.from.col <= t.to.col {
+			return tc.col >= t.from.col && tc.col <= t.to.col
+		}
+		return tc.col >= t.from.col || tc.col <= t.to.col
+	}
+
+	if len(normalized) < len(t.masks) {
+		return false
+	}
+	for pos, mask := range t.masks {
+		if mask&(1<<positionValue(normalized[pos], pos)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compileRangeTerm parses a "FROM-TO" rectangle of cells.
+func compileRangeTerm(term string) (patternTerm, error) {
+	ends := strings.Split(term, "-")
+	if len(ends) != 2 {
+		return patternTerm{}, fmt.Errorf("range %q must have exactly two ends", term)
+	}
+	from, err := parseLocator(strings.TrimSpace(ends[0]))
+	if err != nil {
+		return patternTerm{}, err
+	}
+	to, err := parseLocator(strings.TrimSpace(ends[1]))
+	if err != nil {
+		return patternTerm{}, err
+	}
+	if from.prec != to.prec {
+		return patternTerm{}, fmt.Errorf("range %q ends must have the same precision", term)
+	}
+	if from.row > to.row {
+		from.row, to.row = to.row, from.row
+	}
+	return patternTerm{isRange: true, from: from, to: to}, nil
+}
+
+// compileMaskTerm parses a per-position pattern made of literals, ?, [...] classes and a trailing *.
+func compileMaskTerm(term string) (patternTerm, error) {
+	var masks []uint32
+	wildcardTail := false
+	for i := 0; i < len(term); i++ {
+		pos := len(masks)
+		ch := term[i]
+		if ch == '*' {
+			if i != len(term)-1 {
+				return patternTerm{}, fmt.Errorf("* is only allowed at the end of a pattern")
+			}
+			wildcardTail = true
+			break
+		}
+		if pos >= len(positionValidators) {
+			return patternTerm{}, fmt.Errorf("pattern %q is longer than 6 positions", term)
+		}
+
+		switch ch {
+		case '?':
+			masks = append(masks, positionAll(pos))
+		case '[':
+			end := strings.IndexByte(term[i:], ']')
+			if end < 0 {
+				return patternTerm{}, fmt.Errorf("unterminated character class in %q", term)
+			}
+			mask, err := compileClass(term[i+1:i+end], pos)
+			if err != nil {
+				return patternTerm{}, err
+			}
+			masks = append(masks, mask)
+			i += end
+		default:
+			v, err := classValue(ch, pos)
+			if err != nil {
+				return patternTerm{}, err
+			}
+			masks = append(masks, 1<<v)
+		}
+	}
+
+	// An odd number of positions only makes sense when followed by *, e.g. "IO9*"
+	if len(masks)%2 != 0 {
+		if !wildcardTail {
+			return patternTerm{}, fmt.Errorf("pattern %q must cover 2, 4 or 6 positions or end with *", term)
+		}
+		masks = append(masks, positionAll(len(masks)))
+	}
+	return patternTerm{masks: masks}, nil
+}
+
+// compileClass parses the inside of a [...] character class for the given position.
+func compileClass(class string, pos int) (uint32, error) {
+	negate := false
+	if len(class) > 0 && (class[0] == '!' || class[0] == '^') {
+		negate = true
+		class = class[1:]
+	}
+	if class == "" {
+		return 0, fmt.Errorf("empty character class at position %d", pos+1)
+	}
+
+	var mask uint32
+	for i := 0; i < len(class); i++ {
+		lo, err := classValue(class[i], pos)
+		if err != nil {
+			return 0, err
+		}
+		hi := lo
+		if i+2 < len(class) && class[i+1] == '-' {
+			if hi, err = classValue(class[i+2], pos); err != nil {
+				return 0, err
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid character range %c-%c at position %d", class[i], class[i+2], pos+1)
+			}
+			i += 2
+		}
+		for v := lo; v <= hi; v++ {
+			mask |= 1 << v
+		}
+	}
+	if negate {
+		mask = positionAll(pos) &^ mask
+	}
+	return mask, nil
+}
+
+// classValue validates a pattern character for the given position and returns its value
+// (0-17 for fields, 0-9 for squares, 0-23 for subsquares).
+func classValue(ch byte, pos int) (uint, error) {
+	switch pos {
+	case 0, 1:
+		ch = byte(unicode.ToUpper(rune(ch)))
+	case 4, 5:
+		ch = byte(unicode.ToLower(rune(ch)))
+	}
+	v := positionValidators[pos]
+	ok, err := v.validate(string(ch), 0)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("invalid pattern character %q (%s)", ch, v.errMsg)
+	}
+	return positionValue(ch, pos), nil
+}
+
+// positionValue returns the value of an already normalized locator character at the given position.
+func positionValue(ch byte, pos int) uint {
+	switch pos {
+	case 0, 1:
+		return uint(ch - 'A')
+	case 2, 3:
+		return uint(ch - '0')
+	default:
+		return uint(ch - 'a')
+	}
+}
+
+// positionAll returns a mask allowing every valid value at the given position.
+func positionAll(pos int) uint32 {
+	switch pos {
+	case 0, 1:
+		return 1<<18 - 1
+	case 2, 3:
+		return 1<<10 - 1
+	default:
+		return 1<<24 - 1
+	}
+}
@@ -0,0 +1,81 @@
+package maidenhead
+
+import "testing"
+
+func TestPattern_Match(t *testing.T) {
+	cases := []struct {
+		pattern string
+		match   []string
+		noMatch []string
+	}{
+		{"FN3?", []string{"FN31", "fn39", "FN31pr"}, []string{"FN41", "FN", "FM39"}},
+		{"JN*", []string{"JN", "JN58", "jn58td"}, []string{"JO", "IN58"}},
+		{"IO9[0-3]", []string{"IO90", "IO93", "IO91wm"}, []string{"IO94", "IO89"}},
+		{"JO[!0-8]?", []string{"JO90", "JO99xx"}, []string{"JO01", "JO85"}},
+		{"IO9*", []string{"IO91", "IO90aa", "IO99"}, []string{"IO81", "IO"}},
+		{"JN58t?", []string{"JN58ta", "JN58tx"}, []string{"JN58sa", "JN58"}},
+		{"*", []string{"AA", "RR99xx"}, []string{"BAD"}},
+		{"JO*, IO9?", []string{"JO01", "IO91", "JO"}, []string{"IO81", "IN99"}},
+		{"FN20-FN39", []string{"FN20", "FN39", "FN31pr", "FN25"}, []string{"FN40", "FN19", "FN"}},
+		{"FM05-FN14", []string{"FM05", "FN14", "FM19", "FN00"}, []string{"FM04", "FN15", "FM25"}},
+		{"io-jo", []string{"IO", "JO91", "IO91wm"}, []string{"IN", "KO", "JP"}},
+		{"RA-AA", []string{"RA", "AA", "AA00"}, []string{"BA", "QA"}},
+	}
+	for _, tc := range cases {
+		p, err := CompilePattern(tc.pattern)
+		if err != nil {
+			t.Fatalf("CompilePattern(%q) error: %v", tc.pattern, err)
+		}
+		for _, loc := range tc.match {
+			if !p.Match(loc) {
+				t.Errorf("pattern %q should match %q", tc.pattern, loc)
+			}
+		}
+		for _, loc := range tc.noMatch {
+			if p.Match(loc) {
+				t.Errorf("pattern %q should not match %q", tc.pattern, loc)
+			}
+		}
+	}
+}
+
+func TestCompilePattern_Errors(t *testing.T) {
+	bad := []string{
+		"",           // empty
+		"JO,",        // empty alternative
+		"J*N",        // * not at end
+		"FN3",        // odd length without *
+		"SN",         // S not a valid field letter
+		"FNa1",       // letter in square position
+		"FN3[",       // unterminated class
+		"FN3[]",      // empty class
+		"FN3[5-2]",   // reversed range
+		"JN58tdaa",   // too long
+		"FN20-FN3",   // mismatched range precision
+		"FN20-FN39-", // too many range ends
+		"FN20-XX00",  // invalid range end
+	}
+	for _, s := range bad {
+		if _, err := CompilePattern(s); err == nil {
+			t.Errorf("expected error compiling %q", s)
+		}
+	}
+}
+
+func TestPattern_String(t *testing.T) {
+	p, err := CompilePattern("JO*,IO9?")
+	if err != nil {
+		t.Fatalf("CompilePattern error: %v", err)
+	}
+	if p.String() != "JO*,IO9?" {
+		t.Errorf("String got %q", p.String())
+	}
+}
+
+func TestCompilePattern_MultibyteInput(t *testing.T) {
+	for _, in := range []string{"é", "éé", "JNé", "JN5é", "ĴN58td"} {
+		if _, err := CompilePattern(in); err == nil {
+			t.Errorf("CompilePattern(%q): expected error", in)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	profileSpacingKm    = 0.1   // Target spacing of points in a terrain profile
+	maxProfileSegments  = 5000  // Upper limit on the number of segments in a terrain profile
+	fresnelClearRatio   = 0.6   // Fraction of the first Fresnel zone that must be clear for free-space loss
+	fresnelRadiusFactor = 17.32 // First Fresnel zone radius in meters for distances in km and frequency in GHz
+)
+
+// ProfilePoint is one sample of a terrain profile. Heights are in meters above sea level.
+type ProfilePoint struct {
+	DistanceKm float64 `json:"distance_km"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	GroundM    float64 `json:"ground_m"`
+	// TerrainM is the ground raised by the effective Earth's bulge, as seen from the straight ray.
+	TerrainM       float64 `json:"terrain_m"`
+	RayM           float64 `json:"ray_m"`
+	FresnelRadiusM float64 `json:"fresnel_radius_m"`
+	// ClearanceM is the height of the ray above TerrainM; negative when the terrain blocks it.
+	ClearanceM float64 `json:"clearance_m"`
+}
+
+// PathProfile is a terrain profile along the short path between two locators with line-of-sight and first Fresnel
+// zone analysis.
+type PathProfile struct {
+	LocalGridSquare      string  `json:"localGridSquare"`
+	RemoteGridSquare     string  `json:"remoteGridSquare"`
+	FrequencyMHz         float64 `json:"frequency_mhz"`
+	KFactor              float64 `json:"k_factor"`
+	DistanceKm           float64 `json:"distance_km"`
+	LocalAntennaHeightM  float64 `json:"local_antenna_height_m"`
+	RemoteAntennaHeightM float64 `json:"remote_antenna_height_m"`
+	// Points runs from the local to the remote station, including both ends.
+	Points []ProfilePoint `json:"points"`
+	// Obstructions holds the points where the terrain blocks the direct ray.
+	Obstructions []ProfilePoint `json:"obstructions"`
+	// MinFresnelClearance is the smallest ratio of clearance to first Fresnel zone radius along the path;
+	// 0.6 or more is generally taken as free-space propagation.
+	MinFresnelClearance float64 `json:"min_fresnel_clearance"`
+	LineOfSight         bool    `json:"line_of_sight"`
+	FresnelClear        bool    `json:"fresnel_clear"`
+}
+
+// GetPathProfile samples the terrain along the short path between two locators at roughly 100 m spacing and reports
+// obstructions of the direct ray and the first Fresnel zone clearance at a given frequency. Antenna heights are above
+// local ground at each locator's centre, and Earth curvature is included through the k-factor.
+// Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead locator of the local station (2, 4 or 6 characters)
+//   - remoteGridSquare: The Maidenhead locator of the remote station (2, 4 or 6 characters)
+//   - localHeightM: The local antenna height above ground in meters
+//   - remoteHeightM: The remote antenna height above ground in meters
+//   - frequencyMHz: The operating frequency in MHz
+//   - kFactor: The effective Earth radius factor, or 0 for DefaultKFactor (4/3)
+//
+// Returns:
+//   - *PathProfile: The profile, obstructions and clearance results
+//   - error: An error if a locator or parameter is invalid, or elevation data is missing along the path
+func (d *DEM) GetPathProfile(localGridSquare, remoteGridSquare string, localHeightM, remoteHeightM, frequencyMHz, kFactor float64) (*PathProfile, error) {
+	kFactor, err := validateLinkGeometry(localHeightM, remoteHeightM, kFactor)
+	if err != nil {
+		return nil, err
+	}
+	if frequencyMHz <= 0 || math.IsNaN(frequencyMHz) {
+		return nil, fmt.Errorf("invalid frequency: %v MHz", frequencyMHz)
+	}
+	path, err := newGreatCirclePath(localGridSquare, remoteGridSquare, ShortPath)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := min(max(int(math.Ceil(path.distanceKm/profileSpacingKm)), 1), maxProfileSegments)
+	samples := path.samples(segments)
+	ground := make([]float64, len(samples))
+	for i, s := range samples {
+		if ground[i], err = d.Elevation(s[0], s[1]); err != nil {
+			return nil, fmt.Errorf("failed to read elevation at %.1f km: %w", s[2], err)
+		}
+	}
+
+	profile := &PathProfile{
+		LocalGridSquare:      localGridSquare,
+		RemoteGridSquare:     remoteGridSquare,
+		FrequencyMHz:         frequencyMHz,
+		KFactor:              kFactor,
+		DistanceKm:           math.Round(path.distanceKm*100) / 100,
+		LocalAntennaHeightM:  localHeightM,
+		RemoteAntennaHeightM: remoteHeightM,
+		MinFresnelClearance:  math.Inf(1),
+	}
+	start := ground[0] + localHeightM
+	end := ground[len(ground)-1] + remoteHeightM
+	for i, s := range samples {
+		d1, d2 := s[2], path.distanceKm-s[2]
+		pt := ProfilePoint{
+			DistanceKm:     math.Round(d1*1000) / 1000,
+			Latitude:       s[0],
+			Longitude:      s[1],
+			GroundM:        math.Round(ground[i]*10) / 10,
+			RayM:           start + (end-start)*float64(i)/float64(segments),
+			FresnelRadiusM: fresnelRadiusM(d1, d2, frequencyMHz),
+		}
+		terrain := ground[i] + earthBulgeM(d1, d2, kFactor)
+		clearance := pt.RayM - terrain
+		pt.TerrainM = math.Round(terrain*10) / 10
+		pt.ClearanceM = math.Round(clearance*10) / 10
+
+		if i > 0 && i < segments {
+			if clearance < 0 {
+				profile.Obstructions = append(profile.Obstructions, pt)
+			}
+			if pt.FresnelRadiusM > 0 {
+				profile.MinFresnelClearance = math.Min(profile.MinFresnelClearance, clearance/pt.FresnelRadiusM)
+			}
+		}
+		pt.RayM = math.Round(pt.RayM*10) / 10
+		pt.FresnelRadiusM = math.Round(pt.FresnelRadiusM*10) / 10
+		profile.Points = append(profile.Points, pt)
+	}
+	if math.IsInf(profile.MinFresnelClearance, 1) {
+		profile.MinFresnelClearance = 0 // Too short to have intermediate points
+	}
+	profile.MinFresnelClearance = math.Round(profile.MinFresnelClearance*100) / 100
+	profile.LineOfSight = len(profile.Obstructions) == 0
+	profile.FresnelClear = profile.LineOfSight && profile.MinFresnelClearance >= fresnelClearRatio
+	return profile, nil
+}
+
+// fresnelRadiusM returns the radius in meters of the first Fresnel zone at a point d1Km and d2Km from the two ends of
+// a path at frequencyMHz.
+func fresnelRadiusM(d1Km, d2Km, frequencyMHz float64) float64 {
+	total := d1Km + d2Km
+	if total == 0 {
+		return 0
+	}
+	return fresnelRadiusFactor * math.Sqrt(d1Km*d2Km/(frequencyMHz/1000*total))
+}
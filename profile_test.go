@@ -0,0 +1,110 @@
+package maidenhead
+
+import (
+	"math"
+	"testing"
+)
+
+// ridgeDEM returns a DEM over N48E011 that is flat at 500 m with a north-south ridge of the given height above it at
+// longitude 11.7, between JN58td and JN58vd.
+func ridgeDEM(t *testing.T, ridgeM int16) *DEM {
+	t.Helper()
+	dir := t.TempDir()
+	writeHGT(t, dir, "N48E011.hgt", func(_, c int) int16 {
+		if c >= 838 && c <= 842 {
+			return 500 + ridgeM
+		}
+		return 500
+	})
+	dem, err := OpenDEM(dir)
+	if err != nil {
+		t.Fatalf("OpenDEM error: %v", err)
+	}
+	return dem
+}
+
+func TestGetPathProfile(t *testing.T) {
+	// JN58td to JN58vd is about 12.4 km east-west; at 1296 MHz the midpoint Fresnel radius is about 27 m and the
+	// Earth's bulge about 2 m
+	tests := []struct {
+		name         string
+		ridgeM       int16
+		heightM      float64
+		lineOfSight  bool
+		fresnelClear bool
+	}{
+		{"flat with tall masts", 0, 40, true, true},
+		{"flat with short masts", 0, 10, true, false},
+		{"low ridge in the Fresnel zone", 25, 40, true, false},
+		{"ridge blocking the ray", 60, 40, false, false},
+	}
+	for _, tt := range tests {
+		profile, err := ridgeDEM(t, tt.ridgeM).GetPathProfile("JN58td", "JN58vd", tt.heightM, tt.heightM, 1296, 0)
+		if err != nil {
+			t.Fatalf("%s: GetPathProfile error: %v", tt.name, err)
+		}
+		if profile.LineOfSight != tt.lineOfSight || profile.FresnelClear != tt.fresnelClear {
+			t.Errorf("%s: got line of sight %v, Fresnel clear %v (min clearance %.2f), want %v, %v", tt.name,
+				profile.LineOfSight, profile.FresnelClear, profile.MinFresnelClearance, tt.lineOfSight, tt.fresnelClear)
+		}
+		if tt.lineOfSight != (len(profile.Obstructions) == 0) {
+			t.Errorf("%s: got %d obstructions", tt.name, len(profile.Obstructions))
+		}
+	}
+
+	profile, err := ridgeDEM(t, 60).GetPathProfile("jn58td", "JN58VD", 40, 40, 1296, 0)
+	if err != nil {
+		t.Fatalf("GetPathProfile error: %v", err)
+	}
+	if !almostEqual(profile.DistanceKm, 12.4, 0.1) || profile.KFactor != DefaultKFactor {
+		t.Errorf("unexpected path: %.2f km, k %.3f", profile.DistanceKm, profile.KFactor)
+	}
+	first, last := profile.Points[0], profile.Points[len(profile.Points)-1]
+	if first.DistanceKm != 0 || first.RayM != 540 || last.RayM != 540 || len(profile.Points) < 120 {
+		t.Errorf("unexpected end points: %+v %+v (%d points)", first, last, len(profile.Points))
+	}
+	for _, p := range profile.Obstructions {
+		if p.ClearanceM >= 0 || !almostEqual(p.Longitude, 11.7, 0.01) {
+			t.Errorf("unexpected obstruction: %+v", p)
+		}
+	}
+}
+
+func TestGetPathProfileErrors(t *testing.T) {
+	dem := ridgeDEM(t, 0)
+	if _, err := dem.GetPathProfile("JN58td", "JN58vd", -1, 10, 1296, 0); err == nil {
+		t.Error("expected error for negative antenna height")
+	}
+	if _, err := dem.GetPathProfile("JN58td", "JN58vd", math.NaN(), 10, 1296, 0); err == nil {
+		t.Error("expected error for NaN antenna height")
+	}
+	if _, err := dem.GetPathProfile("JN58td", "JN58vd", 10, math.Inf(1), 1296, 0); err == nil {
+		t.Error("expected error for infinite antenna height")
+	}
+	if _, err := dem.GetPathProfile("JN58td", "JN58vd", 10, 10, 1296, math.Inf(1)); err == nil {
+		t.Error("expected error for infinite k-factor")
+	}
+	if _, err := dem.GetPathProfile("JN58td", "JN58vd", 10, 10, 0, 0); err == nil {
+		t.Error("expected error for zero frequency")
+	}
+	if _, err := dem.GetPathProfile("JN58td", "JN58vd", 10, 10, 1296, -1); err == nil {
+		t.Error("expected error for negative k-factor")
+	}
+	if _, err := dem.GetPathProfile("JN58td", "ZZ99", 10, 10, 1296, 0); err == nil {
+		t.Error("expected error for invalid locator")
+	}
+	// JN47 lies outside the only tile
+	if _, err := dem.GetPathProfile("JN58td", "JN47", 10, 10, 1296, 0); err == nil {
+		t.Error("expected error for missing elevation data")
+	}
+}
+
+func TestFresnelRadiusM(t *testing.T) {
+	// 10 km path at 10 GHz: 8.66 m at the midpoint
+	if got := fresnelRadiusM(5, 5, 10000); !almostEqual(got, 8.66, 0.01) {
+		t.Errorf("got %.3f m want ~8.66", got)
+	}
+	if got := fresnelRadiusM(0, 10, 10000); got != 0 {
+		t.Errorf("radius at the end of the path got %.3f want 0", got)
+	}
+}
@@ -0,0 +1,202 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	sporadicEHeightKm = 110.0 // Typical height of the sporadic-E layer
+	f2HeightKm        = 300.0 // Typical reflection height of the F2 layer
+	minTakeoffAngle   = 3.0   // Lowest practical radiation angle in degrees, used to limit hop length
+	tropoMaxKm        = 2000  // Longest tropospheric (including ducting) path considered plausible
+)
+
+// PropagationMode is a mechanism by which a signal can travel between two stations.
+type PropagationMode int
+
+const (
+	GroundWave      PropagationMode = iota // Surface wave on MF and the lower HF bands
+	Tropospheric                           // Line of sight, tropospheric scatter and ducting on VHF and up
+	SporadicE                              // Reflection from patches of dense E-layer ionization
+	TransEquatorial                        // TEP between stations either side of the geomagnetic equator
+	F2Layer                                // Reflection from the F2 layer
+)
+
+// String returns a human-readable name for the mode.
+func (m PropagationMode) String() string {
+	switch m {
+	case GroundWave:
+		return "ground wave"
+	case Tropospheric:
+		return "tropo"
+	case SporadicE:
+		return "sporadic-E"
+	case TransEquatorial:
+		return "TEP"
+	case F2Layer:
+		return "F2"
+	default:
+		return fmt.Sprintf("PropagationMode(%d)", int(m))
+	}
+}
+
+// ModeEstimate is one plausible propagation mode for a path.
+type ModeEstimate struct {
+	Mode PropagationMode `json:"mode"`
+	// Hops is the number of ionospheric hops, or 0 for ground wave and tropo.
+	Hops int `json:"hops"`
+	// TakeoffAngle is the radiation angle in degrees above the horizon, 0 for ground wave and tropo.
+	TakeoffAngle float64 `json:"takeoff_angle"`
+}
+
+// PropagationEstimate lists the plausible propagation modes between two locators on a band.
+type PropagationEstimate struct {
+	LocalGridSquare  string  `json:"localGridSquare"`
+	RemoteGridSquare string  `json:"remoteGridSquare"`
+	Band             Band    `json:"band"`
+	DistanceKm       float64 `json:"distance_km"`
+	// Modes holds the plausible modes in PropagationMode order (ground wave, tropo, sporadic-E, TEP, F2), not ranked by
+	// likelihood. It is empty if no mode fits, e.g. 70cm over 5000 km.
+	Modes []ModeEstimate `json:"modes"`
+}
+
+// GetPropagationModes classifies the plausible propagation modes between two locators on a band from the short-path
+// distance and geometry alone: ground wave, tropo, sporadic-E (single or double hop), TEP and multi-hop F2, each with
+// a hop count and takeoff angle. It says nothing about whether the band is open now; see it as a tag for a logged QSO.
+// Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead locator of the local station (2, 4 or 6 characters)
+//   - remoteGridSquare: The Maidenhead locator of the remote station (2, 4 or 6 characters)
+//   - band: The band in use
+//
+// Returns:
+//   - *PropagationEstimate: The distance and the plausible modes in PropagationMode order
+//   - error: An error if either locator or the band is invalid
+func GetPropagationModes(localGridSquare, remoteGridSquare string, band Band) (*PropagationEstimate, error) {
+	if !band.Valid() {
+		return nil, fmt.Errorf("unsupported band: %s", band)
+	}
+	localLat, localLon, err := locatorCenter(localGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local grid square: %w", err)
+	}
+	remoteLat, remoteLon, err := locatorCenter(remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote grid square: %w", err)
+	}
+
+	distanceKm := haversineKm(localLat, localLon, remoteLat, remoteLon)
+	freq := band.FrequencyMHz()
+	estimate := &PropagationEstimate{
+		LocalGridSquare:  localGridSquare,
+		RemoteGridSquare: remoteGridSquare,
+		Band:             band,
+		DistanceKm:       math.Ceil(distanceKm),
+		Modes:            []ModeEstimate{},
+	}
+	add := func(mode PropagationMode, hops int, takeoff float64) {
+		estimate.Modes = append(estimate.Modes, ModeEstimate{
+			Mode:         mode,
+			Hops:         hops,
+			TakeoffAngle: math.Round(takeoff*10) / 10,
+		})
+	}
+
+	if distanceKm <= groundWaveRangeKm(freq) {
+		add(GroundWave, 0, 0)
+	}
+	if freq >= 50 && distanceKm <= tropoMaxKm {
+		add(Tropospheric, 0, 0)
+	}
+	if freq >= 28 && freq <= 148 {
+		minEsKm := 500.0
+		if freq > 100 {
+			minEsKm = 1200 // 2m Es needs a long, low-angle hop
+		}
+		hops, takeoff := hopGeometry(distanceKm, sporadicEHeightKm)
+		if distanceKm >= minEsKm && hops <= 2 {
+			add(SporadicE, hops, takeoff)
+		}
+		if distanceKm >= 3000 && distanceKm <= 8000 && crossesGeomagneticEquator(localLat, localLon, remoteLat, remoteLon) {
+			_, takeoff := hopGeometry(distanceKm, f2HeightKm)
+			add(TransEquatorial, 1, math.Max(takeoff, minTakeoffAngle))
+		}
+	}
+	if freq <= 54 && distanceKm >= f2MinDistanceKm(freq) {
+		hops, takeoff := hopGeometry(distanceKm, f2HeightKm)
+		add(F2Layer, hops, takeoff)
+	}
+	return estimate, nil
+}
+
+// groundWaveRangeKm returns a rough useful ground wave range for a frequency in MHz, shrinking as frequency rises.
+func groundWaveRangeKm(freqMHz float64) float64 {
+	switch {
+	case freqMHz < 2:
+		return 250
+	case freqMHz < 4:
+		return 150
+	case freqMHz < 6:
+		return 100
+	case freqMHz < 8:
+		return 80
+	case freqMHz < 11:
+		return 60
+	case freqMHz < 15:
+		return 40
+	case freqMHz <= 30:
+		return 25
+	default:
+		return 0 // Above HF, short paths are line of sight and counted as tropo
+	}
+}
+
+// f2MinDistanceKm returns the shortest plausible F2 path for a frequency in MHz. The lower bands work down to
+// near-vertical incidence, while higher frequencies skip over nearby stations.
+func f2MinDistanceKm(freqMHz float64) float64 {
+	switch {
+	case freqMHz <= 10:
+		return 0
+	case freqMHz <= 30:
+		return 500
+	default:
+		return 2000
+	}
+}
+
+// hopGeometry returns the number of equal hops needed to cover distanceKm via a layer at heightKm without any hop
+// radiating below minTakeoffAngle, and the takeoff angle in degrees of each hop.
+func hopGeometry(distanceKm, heightKm float64) (int, float64) {
+	hops := int(math.Max(1, math.Ceil(distanceKm/maxHopKm(heightKm, minTakeoffAngle))))
+	return hops, takeoffAngle(distanceKm/float64(hops), heightKm)
+}
+
+// maxHopKm returns the ground range of a single hop via a layer at heightKm radiated at elevation degrees.
+func maxHopKm(heightKm, elevation float64) float64 {
+	e := toRadians(elevation)
+	halfAngle := math.Pi/2 - e - math.Asin(earthRad*math.Cos(e)/(earthRad+heightKm))
+	return 2 * earthRad * halfAngle
+}
+
+// takeoffAngle returns the elevation in degrees at which a hop of hopKm via a layer at heightKm leaves the ground.
+func takeoffAngle(hopKm, heightKm float64) float64 {
+	halfAngle := hopKm / (2 * earthRad)
+	return toDegrees(math.Atan2(math.Cos(halfAngle)-earthRad/(earthRad+heightKm), math.Sin(halfAngle)))
+}
+
+// crossesGeomagneticEquator reports whether two points lie either side of the geomagnetic equator at the
+// geomagnetic latitudes (5° to 40°) typical of trans-equatorial propagation.
+func crossesGeomagneticEquator(lat1, lon1, lat2, lon2 float64) bool {
+	// The dipole moves slowly enough that the model epoch is close enough for this test
+	poleLat, poleLon, err := geomagneticPole(time.Date(int(wmmEpoch), 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return false
+	}
+	mlat1, _ := dipoleCoordinates(lat1, lon1, poleLat, poleLon)
+	mlat2, _ := dipoleCoordinates(lat2, lon2, poleLat, poleLon)
+	inBand := func(mlat float64) bool { return math.Abs(mlat) >= 5 && math.Abs(mlat) <= 40 }
+	return mlat1*mlat2 < 0 && inBand(mlat1) && inBand(mlat2)
+}
@@ -0,0 +1,77 @@
+package maidenhead
+
+import "testing"
+
+func TestHopGeometry(t *testing.T) {
+	// Single-hop limits at a 3° takeoff angle are about 1800 km for Es and 3200 km for F2
+	if got := maxHopKm(sporadicEHeightKm, minTakeoffAngle); !almostEqual(got, 1776, 10) {
+		t.Errorf("Es max hop got %.0f want ~1776", got)
+	}
+	if got := maxHopKm(f2HeightKm, minTakeoffAngle); !almostEqual(got, 3225, 10) {
+		t.Errorf("F2 max hop got %.0f want ~3225", got)
+	}
+	if got := takeoffAngle(0, f2HeightKm); !almostEqual(got, 90, 1e-9) {
+		t.Errorf("vertical incidence got %.2f want 90", got)
+	}
+	if got := takeoffAngle(maxHopKm(f2HeightKm, 10), f2HeightKm); !almostEqual(got, 10, 1e-6) {
+		t.Errorf("takeoffAngle should invert maxHopKm, got %.4f", got)
+	}
+
+	hops, angle := hopGeometry(6100, f2HeightKm)
+	if hops != 2 || angle < minTakeoffAngle {
+		t.Errorf("6100 km F2 got %d hops at %.1f°", hops, angle)
+	}
+}
+
+func TestGetPropagationModes(t *testing.T) {
+	tests := []struct {
+		name   string
+		local  string
+		remote string
+		band   Band
+		want   []ModeEstimate // Compared on mode and hops only
+	}{
+		{"160m local", "JO65", "JO75", Band160m, []ModeEstimate{{Mode: GroundWave}, {Mode: F2Layer, Hops: 1}}},
+		{"6m Es single hop", "JO65", "IN83", Band6m, []ModeEstimate{{Mode: Tropospheric}, {Mode: SporadicE, Hops: 1}}},
+		{"6m Es double hop", "JO65", "IM58", Band6m, []ModeEstimate{{Mode: SporadicE, Hops: 2}, {Mode: F2Layer, Hops: 1}}},
+		{"20m transatlantic", "JO65", "FN31", Band20m, []ModeEstimate{{Mode: F2Layer, Hops: 2}}},
+		{"6m TEP", "FK68", "GF05", Band6m, []ModeEstimate{{Mode: TransEquatorial, Hops: 1}, {Mode: F2Layer, Hops: 2}}},
+		{"2m tropo", "JN58", "JN68", Band2m, []ModeEstimate{{Mode: Tropospheric}}},
+		{"70cm beyond tropo", "JO65", "FN31", Band70cm, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetPropagationModes(tt.local, tt.remote, tt.band)
+			if err != nil {
+				t.Fatalf("GetPropagationModes error: %v", err)
+			}
+			if len(got.Modes) != len(tt.want) {
+				t.Fatalf("got modes %v want %v", got.Modes, tt.want)
+			}
+			for i, m := range got.Modes {
+				if m.Mode != tt.want[i].Mode || m.Hops != tt.want[i].Hops {
+					t.Errorf("mode %d got %s/%d want %s/%d", i, m.Mode, m.Hops, tt.want[i].Mode, tt.want[i].Hops)
+				}
+				if m.Hops > 0 && (m.TakeoffAngle < minTakeoffAngle || m.TakeoffAngle > 90) {
+					t.Errorf("mode %s takeoff angle %.1f out of range", m.Mode, m.TakeoffAngle)
+				}
+			}
+		})
+	}
+
+	// The distance matches GetShortPathDistance for 6-character locators
+	got, err := GetPropagationModes("FN31pr", "JO65ha", Band20m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if km, _, _ := GetShortPathDistance("FN31pr", "JO65ha"); got.DistanceKm != km {
+		t.Errorf("distance got %.0f want %.0f", got.DistanceKm, km)
+	}
+
+	if _, err := GetPropagationModes("JO65", "FN31", Band(42)); err == nil {
+		t.Errorf("expected error for invalid band")
+	}
+	if _, err := GetPropagationModes("JO65", "BAD", Band20m); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
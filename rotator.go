@@ -0,0 +1,124 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+)
+
+const maxRotatorOverlap = 180.0 // Largest overlap accepted, giving 540° of travel
+
+// RotatorStop is the direction in which a rotator's mechanical end stop lies.
+type RotatorStop int
+
+const (
+	NorthStop RotatorStop = iota // Travel starts at 0° (north) and runs clockwise, as on most rotators
+	SouthStop                    // Travel starts at -180° (south) and runs clockwise through north
+)
+
+// String returns a human-readable name for the stop position.
+func (s RotatorStop) String() string {
+	switch s {
+	case NorthStop:
+		return "north stop"
+	case SouthStop:
+		return "south stop"
+	default:
+		return fmt.Sprintf("RotatorStop(%d)", int(s))
+	}
+}
+
+// Rotator describes the travel of an azimuth rotator and how the antenna is mounted on it. Positions are in the
+// rotator's own degrees: 0 to 360 plus the overlap for a north stop, or -180 to 180 plus the overlap for a south stop.
+// Create one with NewRotator.
+type Rotator struct {
+	stop    RotatorStop
+	overlap float64
+	offset  float64
+}
+
+// RotatorMove is a planned rotator command for pointing the antenna at a bearing.
+type RotatorMove struct {
+	Bearing  float64 `json:"bearing"`  // Target bearing of the antenna in degrees from true north
+	Position float64 `json:"position"` // Position to command, in rotator degrees
+	Rotation float64 `json:"rotation"` // Signed travel from the current position; positive is clockwise
+	// InOverlap reports that Position lies in the overlap beyond the first full turn.
+	InOverlap bool `json:"in_overlap"`
+}
+
+// NewRotator returns a Rotator for the given stop position, overlap and antenna offset.
+//
+// Parameters:
+//   - stop: NorthStop or SouthStop
+//   - overlap: The travel beyond a full turn in degrees, e.g. 90 for a 450° rotator (0-180)
+//   - offset: The bearing the antenna points at when the rotator reads 0, for antennas not mounted in line with the
+//     rotator's scale
+//
+// Returns:
+//   - *Rotator: The rotator
+//   - error: An error if the stop or overlap is invalid
+func NewRotator(stop RotatorStop, overlap, offset float64) (*Rotator, error) {
+	if stop != NorthStop && stop != SouthStop {
+		return nil, fmt.Errorf("invalid rotator stop: %s", stop)
+	}
+	if overlap < 0 || overlap > maxRotatorOverlap || math.IsNaN(overlap) {
+		return nil, fmt.Errorf("invalid rotator overlap: %v (must be 0-%.0f)", overlap, maxRotatorOverlap)
+	}
+	if math.IsNaN(offset) || math.IsInf(offset, 0) {
+		return nil, fmt.Errorf("invalid antenna offset: %v", offset)
+	}
+	return &Rotator{stop: stop, overlap: overlap, offset: offset}, nil
+}
+
+// Range returns the lowest and highest positions the rotator can reach, in rotator degrees.
+func (r *Rotator) Range() (float64, float64) {
+	start := 0.0
+	if r.stop == SouthStop {
+		start = -180
+	}
+	return start, start + 360 + r.overlap
+}
+
+// Bearing returns the bearing in degrees from true north (0-360) that the antenna points at when the rotator is at
+// position.
+func (r *Rotator) Bearing(position float64) float64 {
+	return normalizeBearing(position + r.offset)
+}
+
+// Plan converts a target bearing, such as one from GetShortPathBearing, into a rotator position that respects the
+// stop, overlap and antenna offset. When the bearing can be reached at two positions because of the overlap, the one
+// needing the shorter rotation from the current position is chosen; the rotator never passes through its stop.
+//
+// Parameters:
+//   - current: The rotator's current position in rotator degrees
+//   - bearing: The target bearing of the antenna in degrees from true north
+//
+// Returns:
+//   - RotatorMove: The position to command and the rotation needed to reach it
+//   - error: An error if the current position is outside the rotator's range or either angle is not finite
+func (r *Rotator) Plan(current, bearing float64) (RotatorMove, error) {
+	lo, hi := r.Range()
+	if current < lo || current > hi || math.IsNaN(current) {
+		return RotatorMove{}, fmt.Errorf("invalid rotator position: %v (must be %.0f to %.0f)", current, lo, hi)
+	}
+	if math.IsNaN(bearing) || math.IsInf(bearing, 0) {
+		return RotatorMove{}, fmt.Errorf("invalid bearing: %v", bearing)
+	}
+
+	// The position within the first turn, then its alternative in the overlap if there is one
+	position := math.Mod(math.Mod(bearing-r.offset-lo, 360)+360, 360) + lo
+	if alt := position + 360; alt <= hi && math.Abs(alt-current) < math.Abs(position-current) {
+		position = alt
+	}
+	position = math.Round(position*10) / 10
+	return RotatorMove{
+		Bearing:   normalizeBearing(bearing),
+		Position:  position,
+		Rotation:  math.Round((position-current)*10) / 10,
+		InOverlap: position > lo+360,
+	}, nil
+}
+
+// normalizeBearing wraps an angle into [0, 360) and rounds it to 0.1 degrees.
+func normalizeBearing(deg float64) float64 {
+	return math.Mod(math.Round(math.Mod(math.Mod(deg, 360)+360, 360)*10)/10, 360)
+}
@@ -0,0 +1,81 @@
+package maidenhead
+
+import "testing"
+
+func TestRotatorPlan(t *testing.T) {
+	north450, _ := NewRotator(NorthStop, 90, 0)
+	south360, _ := NewRotator(SouthStop, 0, 0)
+	south450, _ := NewRotator(SouthStop, 90, 0)
+	offset, _ := NewRotator(NorthStop, 0, 90)
+
+	tests := []struct {
+		name      string
+		rotator   *Rotator
+		current   float64
+		bearing   float64
+		position  float64
+		rotation  float64
+		inOverlap bool
+	}{
+		{"simple move", north450, 10, 20, 20, 10, false},
+		{"stay in the overlap", north450, 400, 20, 380, -20, true},
+		{"turn into the overlap", north450, 350, 10, 370, 20, true},
+		{"overlap too short", north450, 350, 100, 100, -250, false},
+		{"bearing above 360", north450, 0, 370, 10, 10, false},
+		{"negative bearing", north450, 0, -90, 270, 270, false},
+		{"no passing through the south stop", south360, 170, 190, -170, -340, false},
+		{"south stop through north", south360, -10, 10, 10, 20, false},
+		{"south stop overlap", south450, 170, 190, 190, 20, true},
+		{"antenna offset", offset, 180, 90, 0, -180, false},
+		{"antenna offset wraps", offset, 0, 45, 315, 315, false},
+	}
+	for _, tt := range tests {
+		got, err := tt.rotator.Plan(tt.current, tt.bearing)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got.Position != tt.position || got.Rotation != tt.rotation || got.InOverlap != tt.inOverlap {
+			t.Errorf("%s: got %+v want position %v rotation %v overlap %v", tt.name, got, tt.position, tt.rotation,
+				tt.inOverlap)
+		}
+		if b := tt.rotator.Bearing(got.Position); !almostEqual(b, got.Bearing, 1e-9) {
+			t.Errorf("%s: position %v points at %v, not %v", tt.name, got.Position, b, got.Bearing)
+		}
+	}
+}
+
+func TestRotatorRangeAndBearing(t *testing.T) {
+	r, _ := NewRotator(SouthStop, 90, 10)
+	if lo, hi := r.Range(); lo != -180 || hi != 270 {
+		t.Errorf("range got %v to %v want -180 to 270", lo, hi)
+	}
+	if got := r.Bearing(-90); got != 280 {
+		t.Errorf("Bearing(-90) got %v want 280", got)
+	}
+	if got := r.Bearing(260); got != 270 {
+		t.Errorf("Bearing(260) got %v want 270", got)
+	}
+}
+
+func TestRotatorErrors(t *testing.T) {
+	if _, err := NewRotator(RotatorStop(5), 0, 0); err == nil {
+		t.Error("expected error for invalid stop")
+	}
+	if _, err := NewRotator(NorthStop, 200, 0); err == nil {
+		t.Error("expected error for overlap beyond 180")
+	}
+	if _, err := NewRotator(NorthStop, -1, 0); err == nil {
+		t.Error("expected error for negative overlap")
+	}
+	r, _ := NewRotator(NorthStop, 90, 0)
+	if _, err := r.Plan(-5, 10); err == nil {
+		t.Error("expected error for position below the stop")
+	}
+	if _, err := r.Plan(455, 10); err == nil {
+		t.Error("expected error for position beyond the overlap")
+	}
+	if NorthStop.String() != "north stop" || RotatorStop(5).String() != "RotatorStop(5)" {
+		t.Error("unexpected stop names")
+	}
+}
@@ -0,0 +1,229 @@
+package maidenhead
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errRotctldReply marks a reply that does not fit the protocol, after which the connection is out of step.
+var errRotctldReply = errors.New("unexpected rotctld reply")
+
+// RotctldClient controls a rotator through Hamlib's rotctld daemon using its TCP protocol (port 4533 by default).
+// Methods are safe for concurrent use; commands are sent one at a time. After a read or write error, including a
+// timeout, or an unexpected reply, a late reply could be taken as the answer to the next command, so the connection is
+// closed and every later call fails; dial again to recover.
+type RotctldClient struct {
+	mu      sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+	timeout time.Duration
+	rotator *Rotator
+	broken  error // The error that closed the connection, if any
+}
+
+// DialRotctld connects to a rotctld daemon.
+//
+// Parameters:
+//   - address: The daemon's host and port, e.g. "localhost:4533"
+//   - timeout: The limit on connecting and on each command, or 0 for none
+//   - rotator: The rotator's stop, overlap and antenna offset used to plan moves, or nil to command bearings directly
+//     as positions from 0 to 360
+//
+// Returns:
+//   - *RotctldClient: The connected client; call Close when done
+//   - error: An error if the connection fails
+func DialRotctld(address string, timeout time.Duration, rotator *Rotator) (*RotctldClient, error) {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to rotctld: %w", err)
+	}
+	if rotator == nil {
+		rotator = &Rotator{stop: NorthStop}
+	}
+	return &RotctldClient{conn: conn, reader: bufio.NewReader(conn), timeout: timeout, rotator: rotator}, nil
+}
+
+// Close closes the connection to the daemon. It does nothing if the connection was already closed after an error.
+func (c *RotctldClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.broken != nil {
+		return nil
+	}
+	c.broken = net.ErrClosed
+	return c.conn.Close()
+}
+
+// Position returns the rotator's azimuth, in rotator degrees, and elevation.
+func (c *RotctldClient) Position() (float64, float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.position()
+}
+
+// Bearing returns the bearing in degrees from true north that the antenna currently points at, allowing for the
+// rotator's antenna offset.
+func (c *RotctldClient) Bearing() (float64, error) {
+	az, _, err := c.Position()
+	if err != nil {
+		return 0, err
+	}
+	return c.rotator.Bearing(az), nil
+}
+
+// SetPosition commands the rotator to an azimuth, in rotator degrees, and elevation.
+func (c *RotctldClient) SetPosition(azimuth, elevation float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.command(fmt.Sprintf("P %.1f %.1f", azimuth, elevation))
+}
+
+// Stop halts any rotation in progress.
+func (c *RotctldClient) Stop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.command("S")
+}
+
+// PointAt turns the antenna towards a remote locator along the short or long path, computing the bearing with
+// GetShortPathBearing or GetLongPathBearing and planning the move from the current position with Rotator.Plan.
+// The elevation is left unchanged. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead Grid Square of the local station (6 characters)
+//   - remoteGridSquare: The Maidenhead Grid Square of the remote station (6 characters)
+//   - pathType: ShortPath or LongPath
+//
+// Returns:
+//   - RotatorMove: The commanded position and the rotation from the previous position
+//   - error: An error if either grid square or the path type is invalid, or the daemon reports an error
+func (c *RotctldClient) PointAt(localGridSquare, remoteGridSquare string, pathType PathType) (RotatorMove, error) {
+	var bearing float64
+	var err error
+	switch pathType {
+	case ShortPath:
+		bearing, err = GetShortPathBearing(localGridSquare, remoteGridSquare)
+	case LongPath:
+		bearing, err = GetLongPathBearing(localGridSquare, remoteGridSquare)
+	default:
+		return RotatorMove{}, fmt.Errorf("invalid path type: %s", pathType)
+	}
+	if err != nil {
+		return RotatorMove{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	az, el, err := c.position()
+	if err != nil {
+		return RotatorMove{}, err
+	}
+	move, err := c.rotator.Plan(az, bearing)
+	if err != nil {
+		return RotatorMove{}, fmt.Errorf("failed to plan rotator move: %w", err)
+	}
+	if err := c.command(fmt.Sprintf("P %.1f %.1f", move.Position, el)); err != nil {
+		return RotatorMove{}, err
+	}
+	return move, nil
+}
+
+// position sends the get_pos command; the reply is the azimuth and elevation on separate lines.
+func (c *RotctldClient) position() (float64, float64, error) {
+	if err := c.send("p"); err != nil {
+		return 0, 0, err
+	}
+	var values [2]float64
+	for i := range values {
+		line, err := c.readLine()
+		if err != nil {
+			return 0, 0, err
+		}
+		if strings.HasPrefix(line, "RPRT") {
+			// Only an error status can stand in for the position; anything else means the replies are out of step
+			if err := c.checkReply(line); err != nil && i == 0 {
+				return 0, 0, err
+			}
+			return 0, 0, c.fail(fmt.Errorf("%w: status %q in place of a position", errRotctldReply, line))
+		}
+		if values[i], err = strconv.ParseFloat(line, 64); err != nil {
+			return 0, 0, c.fail(fmt.Errorf("%w: invalid position %q", errRotctldReply, line))
+		}
+	}
+	return values[0], values[1], nil
+}
+
+// command sends a command whose only reply is a status line.
+func (c *RotctldClient) command(cmd string) error {
+	if err := c.send(cmd); err != nil {
+		return err
+	}
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	return c.checkReply(line)
+}
+
+// checkReply converts a status line into an error, closing the connection if the line is not a status line.
+func (c *RotctldClient) checkReply(line string) error {
+	err := rotctldError(line)
+	if errors.Is(err, errRotctldReply) {
+		return c.fail(err)
+	}
+	return err
+}
+
+// fail closes the connection after err, so that no later command can read a reply meant for an earlier one.
+func (c *RotctldClient) fail(err error) error {
+	c.broken = err
+	c.conn.Close()
+	return err
+}
+
+// send writes a command line, setting the deadline for the command and its reply.
+func (c *RotctldClient) send(cmd string) error {
+	if c.broken != nil {
+		return fmt.Errorf("rotctld connection closed: %w", c.broken)
+	}
+	if c.timeout > 0 {
+		if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+			return c.fail(fmt.Errorf("failed to set rotctld deadline: %w", err))
+		}
+	}
+	if _, err := c.conn.Write([]byte(cmd + "\n")); err != nil {
+		return c.fail(fmt.Errorf("failed to send rotctld command: %w", err))
+	}
+	return nil
+}
+
+// readLine reads one reply line without its line ending.
+func (c *RotctldClient) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", c.fail(fmt.Errorf("failed to read rotctld reply: %w", err))
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// rotctldError converts an "RPRT n" status line into an error, or nil when n is 0.
+func rotctldError(line string) error {
+	code, ok := strings.CutPrefix(line, "RPRT ")
+	if !ok {
+		return fmt.Errorf("%w: %q", errRotctldReply, line)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(code))
+	if err != nil {
+		return fmt.Errorf("%w: %q", errRotctldReply, line)
+	}
+	if n != 0 {
+		return fmt.Errorf("rotctld error %d", n)
+	}
+	return nil
+}
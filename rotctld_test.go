@@ -0,0 +1,247 @@
+package maidenhead
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRotctld is a minimal rotctld server holding a position and recording the commands it receives.
+type fakeRotctld struct {
+	listener net.Listener
+	mu       sync.Mutex
+	az, el   float64
+	commands []string
+}
+
+func newFakeRotctld(t *testing.T, az, el float64) *fakeRotctld {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	f := &fakeRotctld{listener: l, az: az, el: el}
+	t.Cleanup(func() { l.Close() })
+	go f.serve()
+	return f
+}
+
+func (f *fakeRotctld) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRotctld) handle(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		f.mu.Lock()
+		f.commands = append(f.commands, line)
+		var reply string
+		var az, el float64
+		switch {
+		case line == "p":
+			reply = fmt.Sprintf("%.6f\n%.6f\n", f.az, f.el)
+		case line == "S":
+			reply = "RPRT 0\n"
+		case strings.HasPrefix(line, "P "):
+			if _, err := fmt.Sscanf(line, "P %f %f", &az, &el); err != nil || az < -180 || az > 450 {
+				reply = "RPRT -1\n"
+			} else {
+				f.az, f.el = az, el
+				reply = "RPRT 0\n"
+			}
+		case line == "hang":
+			f.mu.Unlock()
+			continue
+		case line == "slow":
+			// Reply after the client has given up, as a busy daemon might
+			f.mu.Unlock()
+			time.Sleep(300 * time.Millisecond)
+			if _, err := conn.Write([]byte("RPRT 0\n")); err != nil {
+				return
+			}
+			continue
+		default:
+			reply = "RPRT -4\n"
+		}
+		f.mu.Unlock()
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRotctld) received() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.commands...)
+}
+
+func TestRotctldClient(t *testing.T) {
+	server := newFakeRotctld(t, 350, 10)
+	rotator, _ := NewRotator(NorthStop, 90, 0)
+	client, err := DialRotctld(server.listener.Addr().String(), time.Second, rotator)
+	if err != nil {
+		t.Fatalf("DialRotctld error: %v", err)
+	}
+	defer client.Close()
+
+	az, el, err := client.Position()
+	if err != nil || az != 350 || el != 10 {
+		t.Fatalf("Position got %v, %v, %v want 350, 10", az, el, err)
+	}
+
+	// JO62qm lies at 13.7° from JN58td: from 350° the overlap is the shorter way
+	move, err := client.PointAt("JN58td", "JO62qm", ShortPath)
+	if err != nil {
+		t.Fatalf("PointAt error: %v", err)
+	}
+	if move.Position != 373.7 || move.Rotation != 23.7 || !move.InOverlap {
+		t.Errorf("unexpected move: %+v", move)
+	}
+	if got := server.received(); got[len(got)-1] != "P 373.7 10.0" {
+		t.Errorf("last command got %q want %q", got[len(got)-1], "P 373.7 10.0")
+	}
+	if bearing, err := client.Bearing(); err != nil || bearing != 13.7 {
+		t.Errorf("Bearing got %v, %v want 13.7", bearing, err)
+	}
+
+	move, err = client.PointAt("JN58td", "JO62qm", LongPath)
+	if err != nil {
+		t.Fatalf("PointAt long path error: %v", err)
+	}
+	if move.Position != 193.7 || move.Bearing != 193.7 {
+		t.Errorf("unexpected long path move: %+v", move)
+	}
+
+	if err := client.SetPosition(90, 0); err != nil {
+		t.Errorf("SetPosition error: %v", err)
+	}
+	if err := client.Stop(); err != nil {
+		t.Errorf("Stop error: %v", err)
+	}
+	if got := server.received(); got[len(got)-1] != "S" {
+		t.Errorf("last command got %q want S", got[len(got)-1])
+	}
+}
+
+func TestRotctldClientErrors(t *testing.T) {
+	server := newFakeRotctld(t, 0, 0)
+	client, err := DialRotctld(server.listener.Addr().String(), 200*time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("DialRotctld error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.SetPosition(500, 0); err == nil || !strings.Contains(err.Error(), "rotctld error -1") {
+		t.Errorf("expected rotctld error -1, got %v", err)
+	}
+	if _, err := client.PointAt("JN58td", "bad", ShortPath); err == nil {
+		t.Error("expected error for invalid locator")
+	}
+	if _, err := client.PointAt("JN58td", "JO62qm", PathType(7)); err == nil {
+		t.Error("expected error for invalid path type")
+	}
+	if err := client.command("hang"); err == nil {
+		t.Error("expected timeout when the daemon does not reply")
+	}
+	// The connection is closed after the timeout rather than reused
+	if err := client.Stop(); err == nil {
+		t.Error("expected error for a command after a timeout")
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("Close after a failure got %v want nil", err)
+	}
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	closed.Close()
+	if _, err := DialRotctld(closed.Addr().String(), 200*time.Millisecond, nil); err == nil {
+		t.Error("expected error connecting to a closed port")
+	}
+}
+
+func TestRotctldClientLateReply(t *testing.T) {
+	server := newFakeRotctld(t, 0, 0)
+	client, err := DialRotctld(server.listener.Addr().String(), 100*time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("DialRotctld error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.command("slow"); err == nil {
+		t.Fatal("expected timeout waiting for the slow reply")
+	}
+	// Wait for the late "RPRT 0" to arrive; it must not be taken as the reply to the next command
+	time.Sleep(400 * time.Millisecond)
+	if err := client.SetPosition(500, 0); err == nil {
+		t.Error("expected error after a timeout, not the stale success reply")
+	}
+	if _, _, err := client.Position(); err == nil {
+		t.Error("expected error for a position query after a timeout")
+	}
+}
+
+func TestRotctldClientOutOfStep(t *testing.T) {
+	// A daemon that answers every command with a status line, which is wrong for a position query
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			if _, err := conn.Write([]byte("RPRT 0\n")); err != nil {
+				return
+			}
+		}
+	}()
+
+	client, err := DialRotctld(l.Addr().String(), time.Second, nil)
+	if err != nil {
+		t.Fatalf("DialRotctld error: %v", err)
+	}
+	defer client.Close()
+	if _, _, err := client.Position(); err == nil {
+		t.Fatal("expected error for a status line in place of a position")
+	}
+	if err := client.Stop(); err == nil {
+		t.Error("expected error for a command after the replies fell out of step")
+	}
+}
+
+func TestRotctldError(t *testing.T) {
+	tests := []struct {
+		line    string
+		wantErr bool
+	}{
+		{"RPRT 0", false},
+		{"RPRT -8", true},
+		{"garbage", true},
+		{"RPRT x", true},
+	}
+	for _, tt := range tests {
+		if err := rotctldError(tt.line); (err != nil) != tt.wantErr {
+			t.Errorf("rotctldError(%q) got %v, want error %v", tt.line, err, tt.wantErr)
+		}
+	}
+}
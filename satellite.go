@@ -0,0 +1,271 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	wgs84EquatorialRadKm = 6378.137          // WGS-84 semi-major axis in kilometers
+	wgs84Flattening      = 1 / 298.257223563 // WGS-84 flattening
+	earthRotationRadSec  = 7.292115e-5       // Earth rotation rate in radians per second
+	speedOfLightKmSec    = 299792.458        // Speed of light in kilometers per second
+	passSearchStep       = 30 * time.Second  // Coarse step used to find passes before refining edges
+	passTrackStep        = 10 * time.Second  // Spacing of points in SatellitePass.Track
+	passEdgePrecision    = time.Second       // Precision to which AOS and LOS are refined
+)
+
+// Satellite is an initialized SGP4 propagator for one element set.
+// Only near-earth orbits (period under 225 minutes) are supported.
+type Satellite struct {
+	TLE   TLE
+	model *sgp4
+}
+
+// LookAngle is a satellite's position as seen from a station at an instant.
+type LookAngle struct {
+	Time         time.Time `json:"time"`
+	Azimuth      float64   `json:"azimuth"`   // Degrees clockwise from true north
+	Elevation    float64   `json:"elevation"` // Degrees above the horizon
+	RangeKm      float64   `json:"range_km"`
+	RangeRateKmS float64   `json:"range_rate_km_s"` // Positive when the satellite is moving away
+}
+
+// SatellitePass is one pass of a satellite over a station above the minimum elevation.
+type SatellitePass struct {
+	AOS          time.Time   `json:"aos"` // Acquisition of signal
+	TCA          time.Time   `json:"tca"` // Time of closest approach (maximum elevation)
+	LOS          time.Time   `json:"los"` // Loss of signal
+	MaxElevation float64     `json:"max_elevation"`
+	AOSAzimuth   float64     `json:"aos_azimuth"`
+	LOSAzimuth   float64     `json:"los_azimuth"`
+	Track        []LookAngle `json:"track"`
+}
+
+// SatelliteWindow is a period during which a satellite is above the minimum elevation for two stations at once.
+type SatelliteWindow struct {
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+}
+
+// NewSatellite initializes an SGP4 propagator from an element set, e.g. one returned by LoadTLEFile.
+func NewSatellite(tle TLE) (*Satellite, error) {
+	model, err := newSGP4(tle)
+	if err != nil {
+		return nil, err
+	}
+	return &Satellite{TLE: tle, model: model}, nil
+}
+
+// DopplerShift returns the Doppler shift in hertz of a signal at frequencyHz for a given range rate.
+// For a downlink, the received frequency is frequencyHz plus the shift.
+func DopplerShift(frequencyHz, rangeRateKmS float64) float64 {
+	return -frequencyHz * rangeRateKmS / speedOfLightKmSec
+}
+
+// LookAngle calculates the satellite's azimuth, elevation, range and range rate from the centre of a locator.
+// Grid square input is case-insensitive.
+func (s *Satellite) LookAngle(gridSquare string, at time.Time) (*LookAngle, error) {
+	obs, err := newObserver(gridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grid square: %w", err)
+	}
+	look, err := s.lookFrom(obs, at)
+	if err != nil {
+		return nil, err
+	}
+	return &look, nil
+}
+
+// PredictPasses finds every pass of the satellite above minElevation for the centre of a locator between from and to.
+// AOS and LOS are refined to the nearest second, and each pass includes an azimuth/elevation track every
+// 10 seconds with range rates for Doppler correction. A pass in progress at from or to is cut off there.
+// Grid square input is case-insensitive.
+//
+// Parameters:
+//   - gridSquare: The Maidenhead locator of the station (2, 4 or 6 characters)
+//   - from: The start of the search
+//   - to: The end of the search
+//   - minElevation: The lowest usable elevation in degrees
+//
+// Returns:
+//   - []SatellitePass: The passes in chronological order
+//   - error: An error if the locator or time range is invalid, or if propagation fails
+func (s *Satellite) PredictPasses(gridSquare string, from, to time.Time, minElevation float64) ([]SatellitePass, error) {
+	obs, err := newObserver(gridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grid square: %w", err)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid time range: %s to %s", from, to)
+	}
+
+	var propErr error
+	visible := func(t time.Time) bool {
+		look, err := s.lookFrom(obs, t)
+		if err != nil {
+			propErr = err
+			return false
+		}
+		return look.Elevation >= minElevation
+	}
+
+	var passes []SatellitePass
+	for _, span := range s.findVisibility(from, to, visible) {
+		pass := SatellitePass{AOS: span.start, LOS: span.end, MaxElevation: math.Inf(-1)}
+		for t := span.start; ; t = t.Add(passTrackStep) {
+			if t.After(span.end) {
+				t = span.end
+			}
+			look, err := s.lookFrom(obs, t)
+			if err != nil {
+				return nil, err
+			}
+			pass.Track = append(pass.Track, look)
+			if look.Elevation > pass.MaxElevation {
+				pass.MaxElevation, pass.TCA = look.Elevation, t
+			}
+			if !t.Before(span.end) {
+				break
+			}
+		}
+		pass.AOSAzimuth = pass.Track[0].Azimuth
+		pass.LOSAzimuth = pass.Track[len(pass.Track)-1].Azimuth
+		passes = append(passes, pass)
+	}
+	if propErr != nil {
+		return nil, propErr
+	}
+	return passes, nil
+}
+
+// MutualVisibility finds the periods between from and to when the satellite is above minElevation for both stations,
+// i.e. when they can work each other through it. Grid square input is case-insensitive.
+func (s *Satellite) MutualVisibility(localGridSquare, remoteGridSquare string, from, to time.Time, minElevation float64) ([]SatelliteWindow, error) {
+	local, err := newObserver(localGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local grid square: %w", err)
+	}
+	remote, err := newObserver(remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote grid square: %w", err)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid time range: %s to %s", from, to)
+	}
+
+	var propErr error
+	both := func(t time.Time) bool {
+		r, v, err := s.model.propagateAt(t)
+		if err != nil {
+			propErr = err
+			return false
+		}
+		return local.look(r, v, t).Elevation >= minElevation && remote.look(r, v, t).Elevation >= minElevation
+	}
+
+	var windows []SatelliteWindow
+	for _, span := range s.findVisibility(from, to, both) {
+		windows = append(windows, SatelliteWindow{Start: span.start, End: span.end, Duration: span.end.Sub(span.start)})
+	}
+	if propErr != nil {
+		return nil, propErr
+	}
+	return windows, nil
+}
+
+// findVisibility locates the spans where visible holds using a coarse scan, then refines each edge by bisection.
+func (s *Satellite) findVisibility(from, to time.Time, visible func(time.Time) bool) []timeSpan {
+	spans := findWindows(from, to, passSearchStep, visible)
+	for i := range spans {
+		if spans[i].start.After(from) {
+			spans[i].start = refineEdge(spans[i].start.Add(-passSearchStep), spans[i].start, visible)
+		}
+		if spans[i].end.Before(to) {
+			spans[i].end = refineEdge(spans[i].end.Add(-passSearchStep), spans[i].end, visible)
+		}
+	}
+	return spans
+}
+
+// refineEdge narrows the change in visible between lo and hi to passEdgePrecision and returns the first time
+// at which visible has its value at hi.
+func refineEdge(lo, hi time.Time, visible func(time.Time) bool) time.Time {
+	target := visible(hi)
+	for hi.Sub(lo) > passEdgePrecision {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if visible(mid) == target {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi.Round(passEdgePrecision)
+}
+
+// lookFrom propagates the satellite to t and returns its look angle from obs.
+func (s *Satellite) lookFrom(obs observer, t time.Time) (LookAngle, error) {
+	r, v, err := s.model.propagateAt(t)
+	if err != nil {
+		return LookAngle{}, err
+	}
+	return obs.look(r, v, t), nil
+}
+
+// observer is a station location on the WGS-84 ellipsoid at sea level.
+type observer struct {
+	lat, lon float64    // Geodetic latitude and longitude in radians
+	ecef     [3]float64 // Earth-fixed position in kilometers
+}
+
+// newObserver places an observer at the centre of a locator.
+func newObserver(gridSquare string) (observer, error) {
+	lat, lon, err := locatorCenter(gridSquare)
+	if err != nil {
+		return observer{}, err
+	}
+	o := observer{lat: toRadians(lat), lon: toRadians(lon)}
+	e2 := wgs84Flattening * (2 - wgs84Flattening)
+	n := wgs84EquatorialRadKm / math.Sqrt(1-e2*math.Sin(o.lat)*math.Sin(o.lat))
+	o.ecef = [3]float64{
+		n * math.Cos(o.lat) * math.Cos(o.lon),
+		n * math.Cos(o.lat) * math.Sin(o.lon),
+		n * (1 - e2) * math.Sin(o.lat),
+	}
+	return o, nil
+}
+
+// look converts a TEME position and velocity at t into a look angle from the observer.
+func (o observer) look(r, v [3]float64, t time.Time) LookAngle {
+	// Rotate TEME into the Earth-fixed frame using Greenwich mean sidereal time
+	theta := toRadians(greenwichSiderealTime(t))
+	cosT, sinT := math.Cos(theta), math.Sin(theta)
+	pos := [3]float64{cosT*r[0] + sinT*r[1], -sinT*r[0] + cosT*r[1], r[2]}
+	vel := [3]float64{
+		cosT*v[0] + sinT*v[1] + earthRotationRadSec*pos[1],
+		-sinT*v[0] + cosT*v[1] - earthRotationRadSec*pos[0],
+		v[2],
+	}
+
+	rho := [3]float64{pos[0] - o.ecef[0], pos[1] - o.ecef[1], pos[2] - o.ecef[2]}
+	rng := math.Sqrt(rho[0]*rho[0] + rho[1]*rho[1] + rho[2]*rho[2])
+	rangeRate := (rho[0]*vel[0] + rho[1]*vel[1] + rho[2]*vel[2]) / rng
+
+	// Topocentric south-east-zenith components
+	sinLat, cosLat := math.Sin(o.lat), math.Cos(o.lat)
+	sinLon, cosLon := math.Sin(o.lon), math.Cos(o.lon)
+	south := sinLat*cosLon*rho[0] + sinLat*sinLon*rho[1] - cosLat*rho[2]
+	east := -sinLon*rho[0] + cosLon*rho[1]
+	zenith := cosLat*cosLon*rho[0] + cosLat*sinLon*rho[1] + sinLat*rho[2]
+
+	azimuth := math.Mod(toDegrees(math.Atan2(east, -south))+360, 360)
+	return LookAngle{
+		Time:         t,
+		Azimuth:      azimuth,
+		Elevation:    toDegrees(math.Asin(zenith / rng)),
+		RangeKm:      rng,
+		RangeRateKmS: rangeRate,
+	}
+}
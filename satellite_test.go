@@ -0,0 +1,153 @@
+package maidenhead
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func loadTestSatellite(t *testing.T) *Satellite {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "amateur.txt")
+	if err := os.WriteFile(path, []byte("VANGUARD 1\n"+vanguardLine1+"\n"+vanguardLine2+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tles, err := LoadTLEFile(path)
+	if err != nil {
+		t.Fatalf("LoadTLEFile error: %v", err)
+	}
+	sat, err := NewSatellite(tles[0])
+	if err != nil {
+		t.Fatalf("NewSatellite error: %v", err)
+	}
+	return sat
+}
+
+func TestDopplerShift(t *testing.T) {
+	// Approaching at 7 km/s raises a 435 MHz downlink by about 10.2 kHz
+	if got := DopplerShift(435e6, -7); !almostEqual(got, 10157, 1) {
+		t.Errorf("got %.1f Hz want ~10157", got)
+	}
+	if got := DopplerShift(145e6, 0); got != 0 {
+		t.Errorf("got %.1f Hz want 0", got)
+	}
+}
+
+func TestSatellite_LookAngle(t *testing.T) {
+	sat := loadTestSatellite(t)
+	at := sat.TLE.Epoch
+
+	look, err := sat.LookAngle("EL29", at)
+	if err != nil {
+		t.Fatalf("LookAngle error: %v", err)
+	}
+	if look.Azimuth < 0 || look.Azimuth >= 360 || look.Elevation < -90 || look.Elevation > 90 || look.RangeKm <= 0 {
+		t.Errorf("look angle out of range: %+v", look)
+	}
+
+	// Directly beneath the satellite the elevation is ~90° and the range is the height above the surface
+	r, _, err := sat.model.propagateAt(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lat := toDegrees(math.Atan2(r[2], math.Hypot(r[0], r[1])))
+	lon := normalizeLongitude(toDegrees(math.Atan2(r[1], r[0])) - greenwichSiderealTime(at))
+	w, h := PrecisionSubsquare.cellWidth(), PrecisionSubsquare.cellHeight()
+	grid := cell{col: int((lon + 180) / w), row: int((lat + 90) / h), prec: PrecisionSubsquare}.locator()
+	overhead, err := sat.LookAngle(grid, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overhead.Elevation < 80 {
+		t.Errorf("sub-satellite point %s got elevation %.2f want near 90", grid, overhead.Elevation)
+	}
+
+	if _, err := sat.LookAngle("BAD", at); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
+
+func TestSatellite_PredictPasses(t *testing.T) {
+	sat := loadTestSatellite(t)
+	from := time.Date(2000, 6, 28, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	passes, err := sat.PredictPasses("EL29", from, to, 10)
+	if err != nil {
+		t.Fatalf("PredictPasses error: %v", err)
+	}
+	if len(passes) < 3 {
+		t.Fatalf("expected several passes in a day, got %d", len(passes))
+	}
+	for i, p := range passes {
+		if p.TCA.Before(p.AOS) || p.TCA.After(p.LOS) {
+			t.Errorf("pass %d out of order: %s %s %s", i, p.AOS, p.TCA, p.LOS)
+		}
+		if i > 0 && !p.AOS.After(passes[i-1].LOS) {
+			t.Errorf("pass %d overlaps previous", i)
+		}
+		if p.MaxElevation < 10 || p.MaxElevation > 90 {
+			t.Errorf("pass %d max elevation %.2f", i, p.MaxElevation)
+		}
+		first, last := p.Track[0], p.Track[len(p.Track)-1]
+		if !first.Time.Equal(p.AOS) || !last.Time.Equal(p.LOS) {
+			t.Errorf("pass %d track does not span AOS to LOS", i)
+		}
+		// Interior edges are refined to about a second, so the elevation there is close to the minimum
+		if p.AOS.After(from) && !almostEqual(first.Elevation, 10, 0.2) {
+			t.Errorf("pass %d AOS elevation %.3f want ~10", i, first.Elevation)
+		}
+		if p.LOS.Before(to) && !almostEqual(last.Elevation, 10, 0.2) {
+			t.Errorf("pass %d LOS elevation %.3f want ~10", i, last.Elevation)
+		}
+		// The satellite approaches before TCA and recedes after it
+		if first.RangeRateKmS >= 0 && p.AOS.After(from) || last.RangeRateKmS <= 0 && p.LOS.Before(to) {
+			t.Errorf("pass %d range rates %.3f, %.3f", i, first.RangeRateKmS, last.RangeRateKmS)
+		}
+	}
+
+	if _, err := sat.PredictPasses("EL29", to, from, 10); err == nil {
+		t.Errorf("expected error for reversed time range")
+	}
+	if _, err := sat.PredictPasses("BAD", from, to, 10); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
+
+func TestSatellite_MutualVisibility(t *testing.T) {
+	sat := loadTestSatellite(t)
+	from := time.Date(2000, 6, 28, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	windows, err := sat.MutualVisibility("EL29", "DM13", from, to, 0)
+	if err != nil {
+		t.Fatalf("MutualVisibility error: %v", err)
+	}
+	if len(windows) == 0 {
+		t.Fatalf("expected mutual windows between EL29 and DM13")
+	}
+	for _, w := range windows {
+		if w.Duration != w.End.Sub(w.Start) || w.Duration <= 0 {
+			t.Errorf("bad window %+v", w)
+		}
+		mid := w.Start.Add(w.Duration / 2)
+		for _, grid := range []string{"EL29", "DM13"} {
+			look, err := sat.LookAngle(grid, mid)
+			if err != nil || look.Elevation < 0 {
+				t.Errorf("%s elevation at %s got %+v, %v", grid, mid, look, err)
+			}
+		}
+	}
+
+	// Stations on opposite sides of the Earth never see a low satellite together
+	windows, err = sat.MutualVisibility("EL29", "OF86", from, to, 0)
+	if err != nil || len(windows) != 0 {
+		t.Errorf("expected no windows for antipodal stations, got %v, %v", windows, err)
+	}
+
+	if _, err := sat.MutualVisibility("EL29", "BAD", from, to, 0); err == nil {
+		t.Errorf("expected error for invalid remote locator")
+	}
+}
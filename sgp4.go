@@ -0,0 +1,291 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// WGS-72 constants used by SGP4, as in Vallado et al., "Revisiting Spacetrack Report #3" (2006)
+const (
+	sgp4EarthRadKm = 6378.135
+	sgp4Mu         = 398600.8
+	sgp4J2         = 0.001082616
+	sgp4J3         = -0.00000253881
+	sgp4J4         = -0.00000165597
+	sgp4J3oJ2      = sgp4J3 / sgp4J2
+	twoThirds      = 2.0 / 3.0
+	twoPi          = 2 * math.Pi
+
+	deepSpacePeriodMinutes = 225.0 // Orbits with a longer period need the SDP4 deep-space model
+)
+
+// sgp4Xke is sqrt(mu) in units of Earth radii^1.5 per minute
+var sgp4Xke = 60.0 / math.Sqrt(sgp4EarthRadKm*sgp4EarthRadKm*sgp4EarthRadKm/sgp4Mu)
+
+// sgp4 holds the initialized near-earth SGP4 propagator state for one element set.
+type sgp4 struct {
+	epoch time.Time
+
+	// Mean elements (radians, radians/minute)
+	ecco, inclo, nodeo, argpo, mo, no, bstar float64
+
+	isimp                                   bool
+	aycof, con41, cc1, cc4, cc5, d2, d3, d4 float64
+	delmo, eta, argpdot, omgcof, sinmao     float64
+	t2cof, t3cof, t4cof, t5cof, x1mth2      float64
+	x7thm1, mdot, nodedot, xlcof, xmcof     float64
+	nodecf                                  float64
+}
+
+// newSGP4 initializes the near-earth SGP4 model from an element set.
+// Deep-space element sets (period of 225 minutes or more) are rejected.
+func newSGP4(tle TLE) (*sgp4, error) {
+	s := &sgp4{
+		epoch: tle.Epoch,
+		ecco:  tle.Eccentricity,
+		inclo: toRadians(tle.Inclination),
+		nodeo: toRadians(tle.RightAscension),
+		argpo: toRadians(tle.ArgOfPerigee),
+		mo:    toRadians(tle.MeanAnomaly),
+		no:    tle.MeanMotion * twoPi / minutesPerDay,
+		bstar: tle.BStar,
+	}
+	if s.no <= 0 || s.ecco < 0 || s.ecco >= 1 {
+		return nil, fmt.Errorf("invalid orbital elements for satellite %d", tle.CatalogNumber)
+	}
+
+	// Recover the original mean motion and semi-major axis from the Kozai mean motion
+	eccsq := s.ecco * s.ecco
+	omeosq := 1 - eccsq
+	rteosq := math.Sqrt(omeosq)
+	cosio := math.Cos(s.inclo)
+	cosio2 := cosio * cosio
+	ak := math.Pow(sgp4Xke/s.no, twoThirds)
+	d1 := 0.75 * sgp4J2 * (3*cosio2 - 1) / (rteosq * omeosq)
+	del := d1 / (ak * ak)
+	adel := ak * (1 - del*del - del*(1.0/3.0+134*del*del/81))
+	del = d1 / (adel * adel)
+	s.no = s.no / (1 + del)
+
+	if twoPi/s.no >= deepSpacePeriodMinutes {
+		return nil, fmt.Errorf("satellite %d has a deep-space orbit (period %.0f minutes), which is not supported",
+			tle.CatalogNumber, twoPi/s.no)
+	}
+
+	ao := math.Pow(sgp4Xke/s.no, twoThirds)
+	sinio := math.Sin(s.inclo)
+	po := ao * omeosq
+	con42 := 1 - 5*cosio2
+	s.con41 = -con42 - cosio2 - cosio2
+	posq := po * po
+	rp := ao * (1 - s.ecco)
+
+	// Atmospheric drag parameters, adjusting the density reference height for low perigees
+	ss := 78/sgp4EarthRadKm + 1
+	qzms2t := math.Pow((120-78)/sgp4EarthRadKm, 4)
+	s.isimp = rp < 220/sgp4EarthRadKm+1
+	sfour := ss
+	qzms24 := qzms2t
+	perige := (rp - 1) * sgp4EarthRadKm
+	if perige < 156 {
+		sfour = perige - 78
+		if perige < 98 {
+			sfour = 20
+		}
+		qzms24 = math.Pow((120-sfour)/sgp4EarthRadKm, 4)
+		sfour = sfour/sgp4EarthRadKm + 1
+	}
+	pinvsq := 1 / posq
+
+	tsi := 1 / (ao - sfour)
+	s.eta = ao * s.ecco * tsi
+	etasq := s.eta * s.eta
+	eeta := s.ecco * s.eta
+	psisq := math.Abs(1 - etasq)
+	coef := qzms24 * math.Pow(tsi, 4)
+	coef1 := coef / math.Pow(psisq, 3.5)
+	cc2 := coef1 * s.no * (ao*(1+1.5*etasq+eeta*(4+etasq)) +
+		0.375*sgp4J2*tsi/psisq*s.con41*(8+3*etasq*(8+etasq)))
+	s.cc1 = s.bstar * cc2
+	cc3 := 0.0
+	if s.ecco > 1.0e-4 {
+		cc3 = -2 * coef * tsi * sgp4J3oJ2 * s.no * sinio / s.ecco
+	}
+	s.x1mth2 = 1 - cosio2
+	s.cc4 = 2 * s.no * coef1 * ao * omeosq *
+		(s.eta*(2+0.5*etasq) + s.ecco*(0.5+2*etasq) -
+			sgp4J2*tsi/(ao*psisq)*(-3*s.con41*(1-2*eeta+etasq*(1.5-0.5*eeta))+
+				0.75*s.x1mth2*(2*etasq-eeta*(1+etasq))*math.Cos(2*s.argpo)))
+	s.cc5 = 2 * coef1 * ao * omeosq * (1 + 2.75*(etasq+eeta) + eeta*etasq)
+
+	// Secular rates from the zonal harmonics
+	cosio4 := cosio2 * cosio2
+	temp1 := 1.5 * sgp4J2 * pinvsq * s.no
+	temp2 := 0.5 * temp1 * sgp4J2 * pinvsq
+	temp3 := -0.46875 * sgp4J4 * pinvsq * pinvsq * s.no
+	s.mdot = s.no + 0.5*temp1*rteosq*s.con41 + 0.0625*temp2*rteosq*(13-78*cosio2+137*cosio4)
+	s.argpdot = -0.5*temp1*con42 + 0.0625*temp2*(7-114*cosio2+395*cosio4) + temp3*(3-36*cosio2+49*cosio4)
+	xhdot1 := -temp1 * cosio
+	s.nodedot = xhdot1 + (0.5*temp2*(4-19*cosio2)+2*temp3*(3-7*cosio2))*cosio
+	s.omgcof = s.bstar * cc3 * math.Cos(s.argpo)
+	if s.ecco > 1.0e-4 {
+		s.xmcof = -twoThirds * coef * s.bstar / eeta
+	}
+	s.nodecf = 3.5 * omeosq * xhdot1 * s.cc1
+	s.t2cof = 1.5 * s.cc1
+	if math.Abs(cosio+1) > 1.5e-12 {
+		s.xlcof = -0.25 * sgp4J3oJ2 * sinio * (3 + 5*cosio) / (1 + cosio)
+	} else {
+		s.xlcof = -0.25 * sgp4J3oJ2 * sinio * (3 + 5*cosio) / 1.5e-12
+	}
+	s.aycof = -0.5 * sgp4J3oJ2 * sinio
+	s.delmo = math.Pow(1+s.eta*math.Cos(s.mo), 3)
+	s.sinmao = math.Sin(s.mo)
+	s.x7thm1 = 7*cosio2 - 1
+
+	if !s.isimp {
+		cc1sq := s.cc1 * s.cc1
+		s.d2 = 4 * ao * tsi * cc1sq
+		temp := s.d2 * tsi * s.cc1 / 3
+		s.d3 = (17*ao + sfour) * temp
+		s.d4 = 0.5 * temp * ao * tsi * (221*ao + 31*sfour) * s.cc1
+		s.t3cof = s.d2 + 2*cc1sq
+		s.t4cof = 0.25 * (3*s.d3 + s.cc1*(12*s.d2+10*cc1sq))
+		s.t5cof = 0.2 * (3*s.d4 + 12*s.cc1*s.d3 + 6*s.d2*s.d2 + 15*cc1sq*(2*s.d2+cc1sq))
+	}
+	return s, nil
+}
+
+// propagate returns the satellite position (km) and velocity (km/s) in the TEME frame at tsince minutes
+// after the element set epoch.
+func (s *sgp4) propagate(tsince float64) ([3]float64, [3]float64, error) {
+	var r, v [3]float64
+
+	// Secular gravity and atmospheric drag
+	xmdf := s.mo + s.mdot*tsince
+	argpdf := s.argpo + s.argpdot*tsince
+	nodedf := s.nodeo + s.nodedot*tsince
+	argpm := argpdf
+	mm := xmdf
+	t2 := tsince * tsince
+	nodem := nodedf + s.nodecf*t2
+	tempa := 1 - s.cc1*tsince
+	tempe := s.bstar * s.cc4 * tsince
+	templ := s.t2cof * t2
+
+	if !s.isimp {
+		delomg := s.omgcof * tsince
+		delm := s.xmcof * (math.Pow(1+s.eta*math.Cos(xmdf), 3) - s.delmo)
+		temp := delomg + delm
+		mm = xmdf + temp
+		argpm = argpdf - temp
+		t3 := t2 * tsince
+		t4 := t3 * tsince
+		tempa = tempa - s.d2*t2 - s.d3*t3 - s.d4*t4
+		tempe = tempe + s.bstar*s.cc5*(math.Sin(mm)-s.sinmao)
+		templ = templ + s.t3cof*t3 + t4*(s.t4cof+tsince*s.t5cof)
+	}
+
+	am := math.Pow(sgp4Xke/s.no, twoThirds) * tempa * tempa
+	nm := sgp4Xke / math.Pow(am, 1.5)
+	em := s.ecco - tempe
+	if em >= 1 || em < -0.001 {
+		return r, v, fmt.Errorf("sgp4: eccentricity out of range at %.1f minutes", tsince)
+	}
+	if em < 1.0e-6 {
+		em = 1.0e-6
+	}
+	mm = mm + s.no*templ
+	xlm := mm + argpm + nodem
+	nodem = math.Mod(nodem, twoPi)
+	argpm = math.Mod(argpm, twoPi)
+	xlm = math.Mod(xlm, twoPi)
+	mm = math.Mod(xlm-argpm-nodem, twoPi)
+
+	sinip := math.Sin(s.inclo)
+	cosip := math.Cos(s.inclo)
+
+	// Long-period periodics
+	axnl := em * math.Cos(argpm)
+	temp := 1 / (am * (1 - em*em))
+	aynl := em*math.Sin(argpm) + temp*s.aycof
+	xl := mm + argpm + nodem + temp*s.xlcof*axnl
+
+	// Solve Kepler's equation
+	u := math.Mod(xl-nodem, twoPi)
+	eo1 := u
+	tem5 := 9999.9
+	var sineo1, coseo1 float64
+	for ktr := 1; math.Abs(tem5) >= 1.0e-12 && ktr <= 10; ktr++ {
+		sineo1 = math.Sin(eo1)
+		coseo1 = math.Cos(eo1)
+		tem5 = 1 - coseo1*axnl - sineo1*aynl
+		tem5 = (u - aynl*coseo1 + axnl*sineo1 - eo1) / tem5
+		if math.Abs(tem5) >= 0.95 {
+			tem5 = math.Copysign(0.95, tem5)
+		}
+		eo1 += tem5
+	}
+
+	// Short-period preliminary quantities
+	ecose := axnl*coseo1 + aynl*sineo1
+	esine := axnl*sineo1 - aynl*coseo1
+	el2 := axnl*axnl + aynl*aynl
+	pl := am * (1 - el2)
+	if pl < 0 {
+		return r, v, fmt.Errorf("sgp4: semi-latus rectum negative at %.1f minutes", tsince)
+	}
+	rl := am * (1 - ecose)
+	rdotl := math.Sqrt(am) * esine / rl
+	rvdotl := math.Sqrt(pl) / rl
+	betal := math.Sqrt(1 - el2)
+	temp = esine / (1 + betal)
+	sinu := am / rl * (sineo1 - aynl - axnl*temp)
+	cosu := am / rl * (coseo1 - axnl + aynl*temp)
+	su := math.Atan2(sinu, cosu)
+	sin2u := (cosu + cosu) * sinu
+	cos2u := 1 - 2*sinu*sinu
+	temp = 1 / pl
+	temp1 := 0.5 * sgp4J2 * temp
+	temp2 := temp1 * temp
+
+	// Update for short-period periodics
+	mrt := rl*(1-1.5*temp2*betal*s.con41) + 0.5*temp1*s.x1mth2*cos2u
+	su = su - 0.25*temp2*s.x7thm1*sin2u
+	xnode := nodem + 1.5*temp2*cosip*sin2u
+	xinc := s.inclo + 1.5*temp2*cosip*sinip*cos2u
+	mvt := rdotl - nm*temp1*s.x1mth2*sin2u/sgp4Xke
+	rvdot := rvdotl + nm*temp1*(s.x1mth2*cos2u+1.5*s.con41)/sgp4Xke
+
+	// Orientation vectors
+	sinsu, cossu := math.Sin(su), math.Cos(su)
+	snod, cnod := math.Sin(xnode), math.Cos(xnode)
+	sini, cosi := math.Sin(xinc), math.Cos(xinc)
+	xmx := -snod * cosi
+	xmy := cnod * cosi
+	ux := xmx*sinsu + cnod*cossu
+	uy := xmy*sinsu + snod*cossu
+	uz := sini * sinsu
+	vx := xmx*cossu - cnod*sinsu
+	vy := xmy*cossu - snod*sinsu
+	vz := sini * cossu
+
+	if mrt < 1 {
+		return r, v, fmt.Errorf("sgp4: satellite has decayed at %.1f minutes", tsince)
+	}
+
+	vkmpersec := sgp4EarthRadKm * sgp4Xke / 60
+	r = [3]float64{mrt * ux * sgp4EarthRadKm, mrt * uy * sgp4EarthRadKm, mrt * uz * sgp4EarthRadKm}
+	v = [3]float64{
+		(mvt*ux + rvdot*vx) * vkmpersec,
+		(mvt*uy + rvdot*vy) * vkmpersec,
+		(mvt*uz + rvdot*vz) * vkmpersec,
+	}
+	return r, v, nil
+}
+
+// propagateAt returns the TEME position and velocity at time t.
+func (s *sgp4) propagateAt(t time.Time) ([3]float64, [3]float64, error) {
+	return s.propagate(t.Sub(s.epoch).Minutes())
+}
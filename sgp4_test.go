@@ -0,0 +1,63 @@
+package maidenhead
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSGP4_ReferenceVectors(t *testing.T) {
+	// Vallado et al., "Revisiting Spacetrack Report #3", verification output for satellite 00005
+	tle, err := ParseTLE("", vanguardLine1, vanguardLine2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := newSGP4(tle)
+	if err != nil {
+		t.Fatalf("newSGP4 error: %v", err)
+	}
+
+	tests := []struct {
+		tsince float64
+		r      [3]float64
+		v      [3]float64
+	}{
+		{0, [3]float64{7022.46529266, -1400.08296755, 0.03995155}, [3]float64{1.893841015, 6.405893759, 4.534807250}},
+		{360, [3]float64{-7154.03120202, -3783.17682504, -3536.19412294}, [3]float64{4.741887409, -4.151817765, -2.093935425}},
+	}
+	for _, tt := range tests {
+		r, v, err := model.propagate(tt.tsince)
+		if err != nil {
+			t.Fatalf("propagate(%g) error: %v", tt.tsince, err)
+		}
+		for i := range r {
+			if !almostEqual(r[i], tt.r[i], 1e-3) || !almostEqual(v[i], tt.v[i], 1e-6) {
+				t.Errorf("t=%g got r=%v v=%v want r=%v v=%v", tt.tsince, r, v, tt.r, tt.v)
+				break
+			}
+		}
+	}
+
+	// propagateAt measures from the element set epoch
+	r, _, err := model.propagateAt(tle.Epoch.Add(6 * time.Hour))
+	if err != nil || !almostEqual(r[0], tests[1].r[0], 1e-3) {
+		t.Errorf("propagateAt got r=%v err=%v", r, err)
+	}
+}
+
+func TestSGP4_RejectsDeepSpace(t *testing.T) {
+	// A geostationary orbit has a period of about 1436 minutes
+	line1 := "1 28884U 05041A   24122.50000000 -.00000297  00000-0  00000-0 0  9990"
+	line2 := "2 28884   0.0185 268.9811 0002316 140.2355 309.5578  1.00270838 68822"
+	tle, err := ParseTLE("", withTLEChecksum(line1), withTLEChecksum(line2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newSGP4(tle); err == nil {
+		t.Errorf("expected error for deep-space orbit")
+	}
+}
+
+// withTLEChecksum replaces the checksum digit of a TLE line with the correct one.
+func withTLEChecksum(line string) string {
+	return line[:68] + string(rune('0'+tleChecksum(line)))
+}
@@ -0,0 +1,190 @@
+package maidenhead
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"time"
+)
+
+const esMinSpotKm = 500.0 // Shortest spot distance treated as single-hop sporadic-E
+
+// Spot is a reported contact or reception between two stations.
+type Spot struct {
+	Spotter string    `json:"spotter"` // Locator of the receiving station
+	Spotted string    `json:"spotted"` // Locator of the transmitting station
+	Time    time.Time `json:"time"`
+	Band    Band      `json:"band"`
+}
+
+// EsCloud is a cluster of spot midpoints attributed to one sporadic-E cloud.
+type EsCloud struct {
+	Band       Band       `json:"band"`
+	Center     Coordinate `json:"center"`
+	GridSquare string     `json:"gridSquare"` // 4-character square containing Center
+	RadiusKm   float64    `json:"radius_km"`  // Distance from Center to the furthest midpoint
+	Spots      int        `json:"spots"`
+	Start      time.Time  `json:"start"`
+	End        time.Time  `json:"end"`
+	// DriftKmPerHour and DriftBearing give the cloud's motion fitted over its midpoints; both are 0 when the
+	// spots do not span enough time to measure it.
+	DriftKmPerHour float64 `json:"drift_km_per_hour"`
+	DriftBearing   float64 `json:"drift_bearing"`
+}
+
+// GetPathMidpoint returns the great-circle midpoint between the centres of two locators, rounded to 5 decimal places.
+// For single-hop sporadic-E this is where the reflecting cloud sits. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead locator of one station (2, 4 or 6 characters)
+//   - remoteGridSquare: The Maidenhead locator of the other station (2, 4 or 6 characters)
+//
+// Returns:
+//   - *Coordinate: The midpoint of the short path
+//   - error: An error if either locator is invalid
+func GetPathMidpoint(localGridSquare, remoteGridSquare string) (*Coordinate, error) {
+	path, err := newGreatCirclePath(localGridSquare, remoteGridSquare, ShortPath)
+	if err != nil {
+		return nil, err
+	}
+	lat, lon := path.pointAt(path.distanceKm / 2)
+	return &Coordinate{
+		Latitude:  math.Round(lat*rounding) / rounding,
+		Longitude: math.Round(lon*rounding) / rounding,
+	}, nil
+}
+
+// ClusterEsMidpoints groups spots into sporadic-E clouds by the midpoints of their paths. Spots are processed in time
+// order per band; a spot joins a cloud whose centre is within radiusKm of its midpoint and whose latest spot is no more
+// than maxGap earlier, otherwise it starts a new cloud. Spots shorter than 500 km or longer than a single Es hop are
+// ignored, since their midpoints say nothing about where the cloud is.
+//
+// Parameters:
+//   - spots: The spots to cluster, in any order
+//   - radiusKm: The maximum distance of a midpoint from a cloud's centre, typically 300-500 km
+//   - maxGap: The longest quiet period after which a cloud is considered gone
+//
+// Returns:
+//   - []EsCloud: The clouds ordered by start time
+//   - error: An error if the parameters or any spot's locators or band are invalid
+func ClusterEsMidpoints(spots iter.Seq[Spot], radiusKm float64, maxGap time.Duration) ([]EsCloud, error) {
+	if radiusKm <= 0 || maxGap <= 0 {
+		return nil, fmt.Errorf("invalid clustering parameters: radius %v km, gap %s", radiusKm, maxGap)
+	}
+
+	var points []esPoint
+	maxHop := maxHopKm(sporadicEHeightKm, 0)
+	i := 0
+	for spot := range spots {
+		i++
+		if !spot.Band.Valid() {
+			return nil, fmt.Errorf("spot %d: unsupported band: %s", i, spot.Band)
+		}
+		path, err := newGreatCirclePath(spot.Spotter, spot.Spotted, ShortPath)
+		if err != nil {
+			return nil, fmt.Errorf("spot %d: %w", i, err)
+		}
+		if path.distanceKm < esMinSpotKm || path.distanceKm > maxHop {
+			continue
+		}
+		lat, lon := path.pointAt(path.distanceKm / 2)
+		points = append(points, esPoint{lat: lat, lon: lon, time: spot.Time, band: spot.Band})
+	}
+	slices.SortStableFunc(points, func(a, b esPoint) int { return a.time.Compare(b.time) })
+
+	var clusters []*esCluster
+	for _, p := range points {
+		var best *esCluster
+		bestKm := radiusKm
+		for _, c := range clusters {
+			if c.band != p.band || p.time.Sub(c.last) > maxGap {
+				continue
+			}
+			lat, lon := c.center()
+			if d := haversineKm(lat, lon, p.lat, p.lon); d <= bestKm {
+				best, bestKm = c, d
+			}
+		}
+		if best == nil {
+			best = &esCluster{band: p.band}
+			clusters = append(clusters, best)
+		}
+		best.add(p)
+	}
+
+	clouds := make([]EsCloud, 0, len(clusters))
+	for _, c := range clusters {
+		clouds = append(clouds, c.cloud())
+	}
+	return clouds, nil
+}
+
+// esPoint is the midpoint of a single spot.
+type esPoint struct {
+	lat, lon float64
+	time     time.Time
+	band     Band
+}
+
+// esCluster accumulates midpoints, keeping the sum of their unit vectors to find the spherical centroid.
+type esCluster struct {
+	band    Band
+	points  []esPoint
+	x, y, z float64
+	last    time.Time
+}
+
+func (c *esCluster) add(p esPoint) {
+	latRad, lonRad := toRadians(p.lat), toRadians(p.lon)
+	c.x += math.Cos(latRad) * math.Cos(lonRad)
+	c.y += math.Cos(latRad) * math.Sin(lonRad)
+	c.z += math.Sin(latRad)
+	c.points = append(c.points, p)
+	c.last = p.time
+}
+
+// center returns the latitude and longitude of the cluster centroid in degrees.
+func (c *esCluster) center() (float64, float64) {
+	return toDegrees(math.Atan2(c.z, math.Hypot(c.x, c.y))), toDegrees(math.Atan2(c.y, c.x))
+}
+
+// cloud summarizes the cluster, fitting a straight-line drift to the midpoints' east and north offsets over time.
+func (c *esCluster) cloud() EsCloud {
+	lat, lon := c.center()
+	cloud := EsCloud{
+		Band:       c.band,
+		Center:     Coordinate{Latitude: math.Round(lat*rounding) / rounding, Longitude: math.Round(lon*rounding) / rounding},
+		GridSquare: cellAt(lat, lon, PrecisionSquare).locator(),
+		Spots:      len(c.points),
+		Start:      c.points[0].time,
+		End:        c.last,
+	}
+
+	var sumT, sumE, sumN, sumTT, sumTE, sumTN float64
+	n := float64(len(c.points))
+	for _, p := range c.points {
+		d := haversineKm(lat, lon, p.lat, p.lon)
+		cloud.RadiusKm = math.Max(cloud.RadiusKm, d)
+
+		// Local east/north offsets in km from the centre
+		brg := toRadians(rawBearing(lat, lon, p.lat, p.lon))
+		e, north := d*math.Sin(brg), d*math.Cos(brg)
+		t := p.time.Sub(cloud.Start).Hours()
+		sumT += t
+		sumE += e
+		sumN += north
+		sumTT += t * t
+		sumTE += t * e
+		sumTN += t * north
+	}
+	cloud.RadiusKm = math.Round(cloud.RadiusKm)
+
+	if denom := n*sumTT - sumT*sumT; denom > 1e-9 {
+		vEast := (n*sumTE - sumT*sumE) / denom
+		vNorth := (n*sumTN - sumT*sumN) / denom
+		cloud.DriftKmPerHour = math.Round(math.Hypot(vEast, vNorth))
+		cloud.DriftBearing = math.Mod(math.Round(math.Mod(toDegrees(math.Atan2(vEast, vNorth))+360, 360)*10)/10, 360)
+	}
+	return cloud
+}
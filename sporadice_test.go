@@ -0,0 +1,105 @@
+package maidenhead
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// esSpotAt builds a spot whose path midpoint is (lat, lon), with the stations halfKm either side along bearing.
+func esSpotAt(lat, lon, bearing, halfKm float64, at time.Time, band Band) Spot {
+	lat1, lon1 := destinationPoint(lat, lon, bearing, halfKm)
+	lat2, lon2 := destinationPoint(lat, lon, bearing+180, halfKm)
+	return Spot{
+		Spotter: cellAt(lat1, lon1, PrecisionSubsquare).locator(),
+		Spotted: cellAt(lat2, lon2, PrecisionSubsquare).locator(),
+		Time:    at,
+		Band:    band,
+	}
+}
+
+func TestGetPathMidpoint(t *testing.T) {
+	mid, err := GetPathMidpoint("JO65ha", "IN83fe")
+	if err != nil {
+		t.Fatalf("GetPathMidpoint error: %v", err)
+	}
+	localLat, localLon, _ := locatorCenter("JO65ha")
+	remoteLat, remoteLon, _ := locatorCenter("IN83fe")
+	d1 := haversineKm(localLat, localLon, mid.Latitude, mid.Longitude)
+	d2 := haversineKm(remoteLat, remoteLon, mid.Latitude, mid.Longitude)
+	if !almostEqual(d1, d2, 0.01) || !almostEqual(d1+d2, haversineKm(localLat, localLon, remoteLat, remoteLon), 0.01) {
+		t.Errorf("midpoint %+v not halfway: %.2f km and %.2f km", mid, d1, d2)
+	}
+	if _, err := GetPathMidpoint("JO65ha", "BAD"); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
+
+func TestClusterEsMidpoints(t *testing.T) {
+	start := time.Date(2025, 6, 21, 14, 0, 0, 0, time.UTC)
+	var spots []Spot
+
+	// A cloud over northern Germany drifting east at 100 km/h, seen on 6m from several directions
+	for i := 0; i < 12; i++ {
+		at := start.Add(time.Duration(i) * 10 * time.Minute)
+		lat, lon := destinationPoint(52, 8, 90, 100*at.Sub(start).Hours())
+		spots = append(spots, esSpotAt(lat, lon, float64(i*30), 800, at, Band6m))
+	}
+	// A separate cloud over Spain at the same time, and the same German midpoint on 2m
+	for i := 0; i < 3; i++ {
+		at := start.Add(time.Duration(i) * 15 * time.Minute)
+		spots = append(spots, esSpotAt(40.5, -3, float64(i*60), 700, at, Band6m))
+	}
+	spots = append(spots, esSpotAt(52, 8, 45, 700, start, Band2m))
+	// Too short and too long for single-hop Es
+	spots = append(spots, Spot{Spotter: "JO62", Spotted: "JO63", Time: start, Band: Band6m})
+	spots = append(spots, Spot{Spotter: "JO62", Spotted: "FN31", Time: start, Band: Band6m})
+
+	clouds, err := ClusterEsMidpoints(slices.Values(spots), 400, 30*time.Minute)
+	if err != nil {
+		t.Fatalf("ClusterEsMidpoints error: %v", err)
+	}
+	if len(clouds) != 3 {
+		t.Fatalf("expected 3 clouds, got %d: %+v", len(clouds), clouds)
+	}
+
+	germany := clouds[0]
+	if germany.Band != Band6m || germany.Spots != 12 || !germany.End.Equal(start.Add(110*time.Minute)) {
+		t.Errorf("unexpected German cloud: %+v", germany)
+	}
+	if !almostEqual(germany.DriftKmPerHour, 100, 10) || !almostEqual(germany.DriftBearing, 90, 10) {
+		t.Errorf("drift got %.0f km/h at %.1f° want ~100 km/h at ~90°", germany.DriftKmPerHour, germany.DriftBearing)
+	}
+	if germany.GridSquare != "JO41" {
+		t.Errorf("German cloud centre in %s, %+v", germany.GridSquare, germany.Center)
+	}
+
+	var spain, twoMetre *EsCloud
+	for i := range clouds[1:] {
+		if c := &clouds[i+1]; c.Band == Band2m {
+			twoMetre = c
+		} else {
+			spain = c
+		}
+	}
+	if spain == nil || spain.Spots != 3 || spain.GridSquare != "IN80" || spain.RadiusKm > 20 {
+		t.Errorf("unexpected Spanish cloud: %+v", spain)
+	}
+	if twoMetre == nil || twoMetre.Spots != 1 || twoMetre.DriftKmPerHour != 0 {
+		t.Errorf("unexpected 2m cloud: %+v", twoMetre)
+	}
+
+	// After a long gap the same area starts a new cloud
+	later := append(spots[:1:1], esSpotAt(52, 8, 0, 800, start.Add(2*time.Hour), Band6m))
+	clouds, err = ClusterEsMidpoints(slices.Values(later), 400, 30*time.Minute)
+	if err != nil || len(clouds) != 2 {
+		t.Errorf("expected 2 clouds after a gap, got %v, %v", clouds, err)
+	}
+
+	if _, err := ClusterEsMidpoints(slices.Values([]Spot{{Spotter: "JO62", Spotted: "BAD", Band: Band6m}}), 400, time.Hour); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+	if _, err := ClusterEsMidpoints(slices.Values(spots), 0, time.Hour); err == nil {
+		t.Errorf("expected error for zero radius")
+	}
+}
@@ -0,0 +1,198 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// Solar altitudes in degrees that define sunrise/sunset and the twilight boundaries
+	sunriseAltitude      = -0.833 // Upper limb on the horizon, allowing for refraction
+	civilAltitude        = -6.0
+	nauticalAltitude     = -12.0
+	astronomicalAltitude = -18.0
+
+	julianUnixEpoch = 2440587.5 // Julian day at 1970-01-01T00:00:00Z
+	julianJ2000     = 2451545.0 // Julian day at 2000-01-01T12:00:00Z
+	minutesPerDay   = 1440.0
+)
+
+// SunPeriod is the interval of a day during which the Sun is above a given altitude.
+// When the Sun does not cross the altitude that day, Start and End are zero and
+// exactly one of AlwaysAbove or AlwaysBelow is set (polar day or polar night).
+type SunPeriod struct {
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	AlwaysAbove bool      `json:"always_above"`
+	AlwaysBelow bool      `json:"always_below"`
+}
+
+// SunTimes holds sunrise, sunset and twilight times in UTC for a locator on a given day.
+// Daylight runs from sunrise to sunset; each twilight period runs from dawn to dusk for its altitude,
+// so for example civil twilight in the morning is Civil.Start to Daylight.Start.
+type SunTimes struct {
+	GridSquare   string    `json:"gridSquare"`
+	Date         time.Time `json:"date"`
+	Daylight     SunPeriod `json:"daylight"`
+	Civil        SunPeriod `json:"civil"`
+	Nautical     SunPeriod `json:"nautical"`
+	Astronomical SunPeriod `json:"astronomical"`
+}
+
+// Duration returns how long the Sun is above the period's altitude: 24h for AlwaysAbove and 0 for AlwaysBelow.
+func (p SunPeriod) Duration() time.Duration {
+	switch {
+	case p.AlwaysAbove:
+		return 24 * time.Hour
+	case p.AlwaysBelow:
+		return 0
+	default:
+		return p.End.Sub(p.Start)
+	}
+}
+
+// GetSunTimes calculates sunrise, sunset and civil, nautical and astronomical twilight for the centre of a locator.
+// Times are for the solar day whose local noon falls on the UTC calendar date of date, so for stations far from
+// Greenwich some events may fall on the previous or next UTC date. Grid square input is case-insensitive.
+//
+// Parameters:
+//   - gridSquare: The Maidenhead locator of the station (2, 4 or 6 characters)
+//   - date: The day of interest; only its UTC year, month and day are used
+//
+// Returns:
+//   - *SunTimes: The sunrise, sunset and twilight periods in UTC
+//   - error: An error if the locator is invalid
+func GetSunTimes(gridSquare string, date time.Time) (*SunTimes, error) {
+	lat, lon, err := locatorCenter(gridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grid square: %w", err)
+	}
+
+	day := utcDay(date)
+	return &SunTimes{
+		GridSquare:   gridSquare,
+		Date:         day,
+		Daylight:     sunPeriod(lat, lon, day, sunriseAltitude),
+		Civil:        sunPeriod(lat, lon, day, civilAltitude),
+		Nautical:     sunPeriod(lat, lon, day, nauticalAltitude),
+		Astronomical: sunPeriod(lat, lon, day, astronomicalAltitude),
+	}, nil
+}
+
+// sunPeriod calculates when the Sun rises above and sets below altitude on the solar day around day's UTC noon.
+func sunPeriod(lat, lon float64, day time.Time, altitude float64) SunPeriod {
+	var period SunPeriod
+	for _, rising := range []bool{true, false} {
+		event, state := sunCrossing(lat, lon, day, altitude, rising)
+		switch {
+		case state > 0:
+			return SunPeriod{AlwaysAbove: true}
+		case state < 0:
+			return SunPeriod{AlwaysBelow: true}
+		case rising:
+			period.Start = event
+		default:
+			period.End = event
+		}
+	}
+	return period
+}
+
+// sunCrossing finds the time the Sun crosses altitude, rising or setting, refining the Sun's position at the
+// estimated event time. state is +1 if the Sun stays above the altitude all day, -1 if it stays below, otherwise 0.
+func sunCrossing(lat, lon float64, day time.Time, altitude float64, rising bool) (time.Time, int) {
+	latRad := toRadians(lat)
+	t := day.Add(12 * time.Hour)
+	for i := 0; i < 4; i++ {
+		decl, eqTime := solarCoordinates(t)
+		noon := 720 - 4*lon - eqTime // minutes after UTC midnight
+
+		cosHA := (math.Sin(toRadians(altitude)) - math.Sin(latRad)*math.Sin(decl)) / (math.Cos(latRad) * math.Cos(decl))
+		if cosHA > 1 {
+			return time.Time{}, -1
+		}
+		if cosHA < -1 {
+			return time.Time{}, 1
+		}
+
+		offset := 4 * toDegrees(math.Acos(cosHA))
+		if rising {
+			offset = -offset
+		}
+		t = day.Add(time.Duration((noon + offset) * float64(time.Minute)))
+	}
+	return t.Round(time.Second), 0
+}
+
+// sunPosition returns the Sun's elevation and azimuth in degrees at the given point and time.
+// Elevation is geometric (no refraction correction); azimuth is measured clockwise from true north.
+func sunPosition(lat, lon float64, t time.Time) (float64, float64) {
+	decl, eqTime := solarCoordinates(t)
+	return sunPositionAt(lat, lon, t.Sub(utcDay(t)).Minutes(), decl, eqTime)
+}
+
+// sunPositionAt is sunPosition with the solar coordinates already calculated, for evaluating many points
+// at the same instant. minutes is the time of day in minutes after UTC midnight.
+func sunPositionAt(lat, lon, minutes, decl, eqTime float64) (float64, float64) {
+	// True solar time and hour angle (degrees, negative before local solar noon)
+	trueSolarTime := math.Mod(minutes+eqTime+4*lon+minutesPerDay, minutesPerDay)
+	hourAngle := toRadians(trueSolarTime/4 - 180)
+
+	latRad := toRadians(lat)
+	sinElev := math.Sin(latRad)*math.Sin(decl) + math.Cos(latRad)*math.Cos(decl)*math.Cos(hourAngle)
+	elevation := toDegrees(math.Asin(math.Max(-1, math.Min(1, sinElev))))
+
+	azimuth := toDegrees(math.Atan2(
+		math.Sin(hourAngle),
+		math.Cos(hourAngle)*math.Sin(latRad)-math.Tan(decl)*math.Cos(latRad),
+	)) + 180
+	return elevation, math.Mod(azimuth, 360)
+}
+
+// solarCoordinates returns the Sun's declination (radians) and the equation of time (minutes) at t,
+// using the NOAA solar calculator formulas.
+func solarCoordinates(t time.Time) (float64, float64) {
+	jc := (julianDay(t) - julianJ2000) / 36525 // Julian centuries since J2000
+
+	meanLong := math.Mod(280.46646+jc*(36000.76983+jc*0.0003032), 360)
+	meanAnom := toRadians(357.52911 + jc*(35999.05029-0.0001537*jc))
+	eccent := 0.016708634 - jc*(0.000042037+0.0000001267*jc)
+
+	center := math.Sin(meanAnom)*(1.914602-jc*(0.004817+0.000014*jc)) +
+		math.Sin(2*meanAnom)*(0.019993-0.000101*jc) +
+		math.Sin(3*meanAnom)*0.000289
+	omega := toRadians(125.04 - 1934.136*jc)
+	apparentLong := toRadians(meanLong + center - 0.00569 - 0.00478*math.Sin(omega))
+
+	meanObliq := 23 + (26+(21.448-jc*(46.815+jc*(0.00059-jc*0.001813)))/60)/60
+	obliq := toRadians(meanObliq + 0.00256*math.Cos(omega))
+
+	decl := math.Asin(math.Sin(obliq) * math.Sin(apparentLong))
+
+	y := math.Pow(math.Tan(obliq/2), 2)
+	l0 := toRadians(meanLong)
+	eqTime := y*math.Sin(2*l0) - 2*eccent*math.Sin(meanAnom) +
+		4*eccent*y*math.Sin(meanAnom)*math.Cos(2*l0) -
+		0.5*y*y*math.Sin(4*l0) - 1.25*eccent*eccent*math.Sin(2*meanAnom)
+
+	return decl, 4 * toDegrees(eqTime)
+}
+
+// subsolarPoint returns the latitude and longitude where the Sun is directly overhead at t.
+func subsolarPoint(t time.Time) (float64, float64) {
+	decl, eqTime := solarCoordinates(t)
+	minutes := t.Sub(utcDay(t)).Minutes()
+	return toDegrees(decl), normalizeLongitude((720 - minutes - eqTime) / 4)
+}
+
+// julianDay returns the Julian day number (with fraction) for t.
+func julianDay(t time.Time) float64 {
+	return float64(t.UnixNano())/float64(24*time.Hour) + julianUnixEpoch
+}
+
+// utcDay returns midnight UTC at the start of t's UTC calendar date.
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
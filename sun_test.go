@@ -0,0 +1,111 @@
+package maidenhead
+
+import (
+	"testing"
+	"time"
+)
+
+func withinMinutes(got, want time.Time, minutes float64) bool {
+	d := got.Sub(want).Minutes()
+	return d >= -minutes && d <= minutes
+}
+
+func TestGetSunTimes_London(t *testing.T) {
+	// IO91wm (central London), published times in UTC
+	cases := []struct {
+		date            time.Time
+		sunrise, sunset time.Time
+	}{
+		{time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 21, 3, 43, 0, 0, time.UTC), time.Date(2024, 6, 21, 20, 21, 0, 0, time.UTC)},
+		{time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 21, 8, 4, 0, 0, time.UTC), time.Date(2024, 12, 21, 15, 53, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		st, err := GetSunTimes("IO91wm", tc.date)
+		if err != nil {
+			t.Fatalf("GetSunTimes error: %v", err)
+		}
+		if !withinMinutes(st.Daylight.Start, tc.sunrise, 3) {
+			t.Errorf("%s sunrise got %s want ~%s", tc.date.Format(time.DateOnly), st.Daylight.Start, tc.sunrise)
+		}
+		if !withinMinutes(st.Daylight.End, tc.sunset, 3) {
+			t.Errorf("%s sunset got %s want ~%s", tc.date.Format(time.DateOnly), st.Daylight.End, tc.sunset)
+		}
+		// Twilight periods nest around daylight
+		if !(st.Civil.Start.Before(st.Daylight.Start) && st.Civil.End.After(st.Daylight.End)) {
+			t.Errorf("civil twilight does not enclose daylight: %+v", st)
+		}
+		if !st.Nautical.Start.Before(st.Civil.Start) {
+			t.Errorf("nautical dawn not before civil dawn: %+v", st)
+		}
+	}
+}
+
+func TestGetSunTimes_SunriseElevation(t *testing.T) {
+	st, err := GetSunTimes("FN31pr", time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetSunTimes error: %v", err)
+	}
+	lat, lon, _ := locatorCenter("FN31pr")
+	for _, ev := range []struct {
+		at  time.Time
+		alt float64
+	}{
+		{st.Daylight.Start, sunriseAltitude},
+		{st.Daylight.End, sunriseAltitude},
+		{st.Civil.Start, civilAltitude},
+		{st.Astronomical.End, astronomicalAltitude},
+	} {
+		elev, _ := sunPosition(lat, lon, ev.at)
+		if !almostEqual(elev, ev.alt, 0.05) {
+			t.Errorf("elevation at %s got %.3f want %.3f", ev.at, elev, ev.alt)
+		}
+	}
+}
+
+func TestGetSunTimes_Polar(t *testing.T) {
+	// JP99 (northern Norway): midnight sun in June, polar night in December
+	summer, err := GetSunTimes("JP99", time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetSunTimes error: %v", err)
+	}
+	if !summer.Daylight.AlwaysAbove || !summer.Daylight.Start.IsZero() || summer.Daylight.Duration() != 24*time.Hour {
+		t.Errorf("expected polar day in June: %+v", summer.Daylight)
+	}
+
+	winter, err := GetSunTimes("JP99", time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetSunTimes error: %v", err)
+	}
+	if !winter.Daylight.AlwaysBelow || winter.Daylight.Duration() != 0 {
+		t.Errorf("expected polar night in December: %+v", winter.Daylight)
+	}
+	if winter.Civil.AlwaysBelow || winter.Civil.Start.IsZero() {
+		t.Errorf("expected civil twilight around noon in December: %+v", winter.Civil)
+	}
+}
+
+func TestGetSunTimes_InvalidLocator(t *testing.T) {
+	if _, err := GetSunTimes("XX", time.Now()); err == nil {
+		t.Errorf("expected error for invalid locator")
+	}
+}
+
+func TestSunPosition_NoonAzimuth(t *testing.T) {
+	// Near local solar noon in the northern hemisphere the Sun is due south
+	elev, az := sunPosition(51.5, 0, time.Date(2024, 6, 21, 12, 2, 0, 0, time.UTC))
+	if !almostEqual(az, 180, 2) {
+		t.Errorf("noon azimuth got %.2f want ~180", az)
+	}
+	if !almostEqual(elev, 90-51.5+23.44, 0.5) {
+		t.Errorf("noon elevation got %.2f want ~%.2f", elev, 90-51.5+23.44)
+	}
+}
+
+func TestGetSunTimes_MultibyteInput(t *testing.T) {
+	date := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	for _, in := range []string{"é", "éé", "JNé", "JN58tđ", "ĴN58td"} {
+		if _, err := GetSunTimes(in, date); err == nil {
+			t.Errorf("GetSunTimes(%q): expected error", in)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package maidenhead
+
+import (
+	"math"
+	"time"
+)
+
+const terminatorStep = 1.0 // Longitude spacing in degrees of the points returned in Terminator.Line
+
+// Terminator describes the day/night boundary at an instant and the squares currently in the grey line.
+type Terminator struct {
+	Time     time.Time  `json:"time"`
+	Subsolar Coordinate `json:"subsolar"`
+	// Line holds points where the Sun's centre is on the horizon, one per degree of longitude from -180° to 180°.
+	Line []Coordinate `json:"line"`
+	// Squares holds every 4-character square with any part in the grey line band (Sun between -6° and -0.833°).
+	Squares *LocatorSet `json:"squares"`
+}
+
+// GetTerminator calculates the grey-line terminator at an instant: the line where the Sun is on the horizon and the
+// set of squares within the twilight band, e.g. for filtering spots to grids currently in the grey line.
+//
+// Parameters:
+//   - at: The time of interest
+//
+// Returns:
+//   - *Terminator: The subsolar point, terminator line and grey line squares
+func GetTerminator(at time.Time) *Terminator {
+	at = at.UTC()
+	decl, eqTime := solarCoordinates(at)
+	minutes := at.Sub(utcDay(at)).Minutes()
+	subLat, subLon := subsolarPoint(at)
+
+	t := &Terminator{
+		Time:     at,
+		Subsolar: Coordinate{Latitude: subLat, Longitude: subLon},
+	}
+
+	// Solve sin(lat)sin(decl) + cos(lat)cos(decl)cos(H) = 0 for each longitude
+	tanDecl := math.Tan(decl)
+	if math.Abs(tanDecl) < 1e-9 {
+		tanDecl = math.Copysign(1e-9, tanDecl)
+	}
+	for lon := -180.0; lon <= 180.0; lon += terminatorStep {
+		hourAngle := toRadians(lon - subLon)
+		lat := toDegrees(math.Atan(-math.Cos(hourAngle) / tanDecl))
+		t.Line = append(t.Line, Coordinate{Latitude: lat, Longitude: lon})
+	}
+
+	t.Squares, _ = NewLocatorSet(PrecisionSquare)
+	for sq := range Squares() {
+		c, _ := parseLocator(sq)
+		if cellInGreyline(c.bounds(), minutes, decl, eqTime) {
+			_ = t.Squares.Add(sq)
+		}
+	}
+	return t
+}
+
+// cellInGreyline reports whether any part of the cell lies in the grey line band, judged from the solar elevation
+// at its corners and centre.
+func cellInGreyline(b BoundingBox, minutes, decl, eqTime float64) bool {
+	minElev, maxElev := math.Inf(1), math.Inf(-1)
+	for _, p := range [][2]float64{
+		{b.MinLat, b.MinLon}, {b.MinLat, b.MaxLon}, {b.MaxLat, b.MinLon}, {b.MaxLat, b.MaxLon},
+		{(b.MinLat + b.MaxLat) / 2, (b.MinLon + b.MaxLon) / 2},
+	} {
+		elev, _ := sunPositionAt(p[0], p[1], minutes, decl, eqTime)
+		minElev = math.Min(minElev, elev)
+		maxElev = math.Max(maxElev, elev)
+	}
+	return minElev <= sunriseAltitude && maxElev >= civilAltitude
+}
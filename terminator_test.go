@@ -0,0 +1,48 @@
+package maidenhead
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetTerminator_LineOnHorizon(t *testing.T) {
+	at := time.Date(2024, 6, 21, 18, 30, 0, 0, time.UTC)
+	term := GetTerminator(at)
+	if len(term.Line) != 361 {
+		t.Fatalf("line points got %d want 361", len(term.Line))
+	}
+	for _, p := range term.Line {
+		elev, _ := sunPosition(p.Latitude, p.Longitude, at)
+		if !almostEqual(elev, 0, 0.01) {
+			t.Fatalf("terminator point %+v has solar elevation %.4f", p, elev)
+		}
+	}
+
+	elev, _ := sunPosition(term.Subsolar.Latitude, term.Subsolar.Longitude, at)
+	if !almostEqual(elev, 90, 0.01) {
+		t.Errorf("subsolar point elevation got %.4f want 90", elev)
+	}
+}
+
+func TestGetTerminator_Squares(t *testing.T) {
+	at := time.Date(2024, 3, 20, 6, 0, 0, 0, time.UTC)
+	term := GetTerminator(at)
+	n := term.Squares.Count()
+	if n == 0 || n > PrecisionSquare.Count()/4 {
+		t.Fatalf("unexpected grey line square count %d", n)
+	}
+	for sq := range term.Squares.All() {
+		lat, lon, _ := locatorCenter(sq)
+		if elev, _ := sunPosition(lat, lon, at); elev < civilAltitude-3 || elev > sunriseAltitude+3 {
+			t.Errorf("square %s centre elevation %.2f far outside grey line band", sq, elev)
+		}
+	}
+	// Near the March equinox at 06 UTC the sunrise terminator runs roughly along 0° longitude,
+	// so equatorial Africa around JJ00 is in twilight while Asia is in daylight
+	if !term.Squares.Contains("JJ00") {
+		t.Errorf("expected JJ00 to be in the grey line")
+	}
+	if term.Squares.Contains("NL00") {
+		t.Errorf("did not expect NL00 to be in the grey line")
+	}
+}
@@ -0,0 +1,201 @@
+package maidenhead
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TLE holds the orbital elements of a satellite parsed from a NORAD two-line element set.
+// Angles are in degrees and MeanMotion is in revolutions per day, as written in the element set.
+type TLE struct {
+	Name           string    `json:"name"`
+	CatalogNumber  int       `json:"catalog_number"`
+	Epoch          time.Time `json:"epoch"`
+	BStar          float64   `json:"bstar"`
+	Inclination    float64   `json:"inclination"`
+	RightAscension float64   `json:"right_ascension"`
+	Eccentricity   float64   `json:"eccentricity"`
+	ArgOfPerigee   float64   `json:"arg_of_perigee"`
+	MeanAnomaly    float64   `json:"mean_anomaly"`
+	MeanMotion     float64   `json:"mean_motion"`
+	Line1          string    `json:"line1"`
+	Line2          string    `json:"line2"`
+}
+
+// LoadTLEFile reads every element set from a TLE file on disk, in either the two-line or the three-line
+// (name followed by two lines) format.
+func LoadTLEFile(path string) ([]TLE, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open TLE file: %w", err)
+	}
+	defer f.Close()
+	return ParseTLEs(f)
+}
+
+// ParseTLEs reads every element set from r, in either the two-line or the three-line format.
+// Blank lines are ignored; checksums are verified.
+func ParseTLEs(r io.Reader) ([]TLE, error) {
+	var (
+		tles  []TLE
+		name  string
+		line1 string
+	)
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), " \r")
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case strings.HasPrefix(line, "1 ") && line1 == "":
+			line1 = line
+		case strings.HasPrefix(line, "2 ") && line1 != "":
+			tle, err := ParseTLE(name, line1, line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			tles = append(tles, tle)
+			name, line1 = "", ""
+		case line1 == "":
+			name = strings.TrimSpace(strings.TrimPrefix(line, "0 "))
+		default:
+			return nil, fmt.Errorf("line %d: expected line 2 of element set", lineNum)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read TLE data: %w", err)
+	}
+	if line1 != "" {
+		return nil, fmt.Errorf("incomplete element set at end of TLE data")
+	}
+	return tles, nil
+}
+
+// ParseTLE parses a single element set from its two data lines. name may be empty.
+func ParseTLE(name, line1, line2 string) (TLE, error) {
+	if len(line1) < 69 || len(line2) < 69 {
+		return TLE{}, fmt.Errorf("invalid TLE: lines must be 69 characters")
+	}
+	for i, line := range []string{line1, line2} {
+		if line[0] != byte('1'+i) {
+			return TLE{}, fmt.Errorf("invalid TLE: line %d must start with %d", i+1, i+1)
+		}
+		if err := verifyTLEChecksum(line); err != nil {
+			return TLE{}, fmt.Errorf("invalid TLE line %d: %w", i+1, err)
+		}
+	}
+
+	tle := TLE{Name: name, Line1: line1, Line2: line2}
+	p := tleFieldParser{}
+	tle.CatalogNumber = p.integer(line1[2:7], "catalog number")
+	if sat2 := p.integer(line2[2:7], "catalog number"); p.err == nil && sat2 != tle.CatalogNumber {
+		return TLE{}, fmt.Errorf("invalid TLE: catalog numbers differ between lines (%d, %d)", tle.CatalogNumber, sat2)
+	}
+	year := p.integer(line1[18:20], "epoch year")
+	dayOfYear := p.float(line1[20:32], "epoch day")
+	tle.BStar = p.exponent(line1[53:61], "bstar")
+	tle.Inclination = p.float(line2[8:16], "inclination")
+	tle.RightAscension = p.float(line2[17:25], "right ascension")
+	tle.Eccentricity = p.float("0."+strings.TrimSpace(line2[26:33]), "eccentricity")
+	tle.ArgOfPerigee = p.float(line2[34:42], "argument of perigee")
+	tle.MeanAnomaly = p.float(line2[43:51], "mean anomaly")
+	tle.MeanMotion = p.float(line2[52:63], "mean motion")
+	if p.err != nil {
+		return TLE{}, p.err
+	}
+
+	// Two-digit years 57-99 are 1957-1999, 00-56 are 2000-2056
+	if year < 57 {
+		year += 2000
+	} else {
+		year += 1900
+	}
+	tle.Epoch = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).
+		Add(time.Duration((dayOfYear - 1) * float64(24*time.Hour))).Round(time.Microsecond)
+	return tle, nil
+}
+
+// verifyTLEChecksum checks the modulo-10 checksum in column 69 of a TLE line.
+func verifyTLEChecksum(line string) error {
+	if got, want := tleChecksum(line), int(line[68]-'0'); got != want {
+		return fmt.Errorf("checksum mismatch: got %d want %d", got, want)
+	}
+	return nil
+}
+
+// tleChecksum returns the modulo-10 checksum of the first 68 columns of a TLE line: the sum of its digits, counting
+// each minus sign as 1.
+func tleChecksum(line string) int {
+	sum := 0
+	for _, ch := range line[:68] {
+		switch {
+		case ch >= '0' && ch <= '9':
+			sum += int(ch - '0')
+		case ch == '-':
+			sum++
+		}
+	}
+	return sum % 10
+}
+
+// tleFieldParser parses fixed-width TLE fields, remembering the first error encountered.
+type tleFieldParser struct {
+	err error
+}
+
+func (p *tleFieldParser) integer(field, what string) int {
+	if p.err != nil {
+		return 0
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(field))
+	if err != nil {
+		p.err = fmt.Errorf("invalid TLE %s %q", what, field)
+	}
+	return v
+}
+
+func (p *tleFieldParser) float(field, what string) float64 {
+	if p.err != nil {
+		return 0
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+	if err != nil {
+		p.err = fmt.Errorf("invalid TLE %s %q", what, field)
+	}
+	return v
+}
+
+// exponent parses the TLE assumed-decimal exponent notation, e.g. " 28098-4" is 0.28098e-4.
+func (p *tleFieldParser) exponent(field, what string) float64 {
+	if p.err != nil {
+		return 0
+	}
+	s := strings.TrimSpace(field)
+	if len(s) < 2 {
+		p.err = fmt.Errorf("invalid TLE %s %q", what, field)
+		return 0
+	}
+	mantissa, exp := s[:len(s)-2], s[len(s)-2:]
+	sign := 1.0
+	switch {
+	case strings.HasPrefix(mantissa, "-"):
+		sign, mantissa = -1, mantissa[1:]
+	case strings.HasPrefix(mantissa, "+"):
+		mantissa = mantissa[1:]
+	}
+	m, err1 := strconv.ParseFloat("0."+mantissa, 64)
+	e, err2 := strconv.Atoi(exp)
+	if err1 != nil || err2 != nil {
+		p.err = fmt.Errorf("invalid TLE %s %q", what, field)
+		return 0
+	}
+	return sign * m * math.Pow(10, float64(e))
+}
@@ -0,0 +1,100 @@
+package maidenhead
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	vanguardLine1 = "1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753"
+	vanguardLine2 = "2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667"
+)
+
+func TestParseTLE(t *testing.T) {
+	tle, err := ParseTLE("VANGUARD 1", vanguardLine1, vanguardLine2)
+	if err != nil {
+		t.Fatalf("ParseTLE error: %v", err)
+	}
+	if tle.Name != "VANGUARD 1" || tle.CatalogNumber != 5 {
+		t.Errorf("got name %q catalog %d", tle.Name, tle.CatalogNumber)
+	}
+	wantEpoch := time.Date(2000, 6, 27, 18, 50, 19, 733568000, time.UTC)
+	if !tle.Epoch.Equal(wantEpoch) {
+		t.Errorf("epoch got %s want %s", tle.Epoch, wantEpoch)
+	}
+	if !almostEqual(tle.BStar, 0.28098e-4, 1e-12) {
+		t.Errorf("bstar got %g want 0.28098e-4", tle.BStar)
+	}
+	if tle.Inclination != 34.2682 || tle.RightAscension != 348.7242 || tle.Eccentricity != 0.1859667 {
+		t.Errorf("unexpected elements: %+v", tle)
+	}
+	if tle.ArgOfPerigee != 331.7664 || tle.MeanAnomaly != 19.3264 || tle.MeanMotion != 10.82419157 {
+		t.Errorf("unexpected elements: %+v", tle)
+	}
+}
+
+func TestParseTLE_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		line1 string
+		line2 string
+	}{
+		{"short line", vanguardLine1[:60], vanguardLine2},
+		{"bad checksum", vanguardLine1[:68] + "0", vanguardLine2},
+		{"swapped lines", vanguardLine2, vanguardLine1},
+		{"catalog mismatch", vanguardLine1, withTLEChecksum("2 00006" + vanguardLine2[7:])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTLE("", tt.line1, tt.line2); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestParseTLEs(t *testing.T) {
+	data := strings.Join([]string{
+		"VANGUARD 1",
+		vanguardLine1,
+		vanguardLine2,
+		"",
+		vanguardLine1,
+		vanguardLine2,
+	}, "\n")
+	tles, err := ParseTLEs(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseTLEs error: %v", err)
+	}
+	if len(tles) != 2 || tles[0].Name != "VANGUARD 1" || tles[1].Name != "" {
+		t.Fatalf("got %+v", tles)
+	}
+
+	if _, err := ParseTLEs(strings.NewReader(vanguardLine1)); err == nil {
+		t.Errorf("expected error for incomplete element set")
+	}
+	if _, err := ParseTLEs(strings.NewReader(vanguardLine1 + "\nNAME\n")); err == nil {
+		t.Errorf("expected error for missing line 2")
+	}
+}
+
+func TestLoadTLEFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vanguard.tle")
+	if err := os.WriteFile(path, []byte("0 VANGUARD 1\r\n"+vanguardLine1+"\r\n"+vanguardLine2+"\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tles, err := LoadTLEFile(path)
+	if err != nil {
+		t.Fatalf("LoadTLEFile error: %v", err)
+	}
+	if len(tles) != 1 || tles[0].Name != "VANGUARD 1" {
+		t.Errorf("got %+v", tles)
+	}
+
+	if _, err := LoadTLEFile(filepath.Join(t.TempDir(), "missing.tle")); err == nil {
+		t.Errorf("expected error for missing file")
+	}
+}
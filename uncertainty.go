@@ -0,0 +1,168 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+)
+
+const uncertaintySamplesPerEdge = 8 // Number of segments each cell edge is split into when sampling for extremes
+
+// PathRange describes a distance and bearing together with the bounds implied by the size of the two grid cells.
+// Bearings run clockwise from MinBearing to MaxBearing, so MinBearing may be greater than MaxBearing when the
+// range wraps through north. A range of 0 to 360 means the bearing is undetermined (the cells touch or overlap).
+type PathRange struct {
+	DistanceKm    float64 `json:"distance_km"`
+	MinDistanceKm float64 `json:"min_distance_km"`
+	MaxDistanceKm float64 `json:"max_distance_km"`
+	Bearing       float64 `json:"bearing"`
+	MinBearing    float64 `json:"min_bearing"`
+	MaxBearing    float64 `json:"max_bearing"`
+}
+
+// PathUncertainty holds the short and long path ranges between two locators of any supported precision.
+type PathUncertainty struct {
+	LocalGridSquare  string    `json:"localGridSquare"`
+	RemoteGridSquare string    `json:"remoteGridSquare"`
+	ShortPath        PathRange `json:"short_path"`
+	LongPath         PathRange `json:"long_path"`
+}
+
+// DistanceUncertaintyKm returns the largest deviation of the distance bounds from the centre-to-centre distance,
+// suitable for display as "DistanceKm ± DistanceUncertaintyKm".
+func (r PathRange) DistanceUncertaintyKm() float64 {
+	return math.Max(r.DistanceKm-r.MinDistanceKm, r.MaxDistanceKm-r.DistanceKm)
+}
+
+// BearingUncertainty returns the largest deviation in degrees of the bearing bounds from the centre-to-centre bearing.
+func (r PathRange) BearingUncertainty() float64 {
+	if r.MinBearing == 0 && r.MaxBearing == 360 {
+		return 180
+	}
+	below := math.Mod(r.Bearing-r.MinBearing+360, 360)
+	above := math.Mod(r.MaxBearing-r.Bearing+360, 360)
+	return math.Round(math.Max(below, above)*10) / 10
+}
+
+// GetPathUncertainty calculates the centre-to-centre short and long path between two locators together with the
+// range of distances and bearings possible for stations anywhere inside each cell. Unlike GetShortPathDistance it
+// accepts fields and squares as well as subsquares, so a 4-character locator yields bounds of roughly ±100 km.
+// Grid square input is case-insensitive.
+//
+// Parameters:
+//   - localGridSquare: The Maidenhead locator of the local station (2, 4 or 6 characters)
+//   - remoteGridSquare: The Maidenhead locator of the remote station (2, 4 or 6 characters)
+//
+// Returns:
+//   - *PathUncertainty: The short and long path ranges
+//   - error: An error if either locator is invalid
+func GetPathUncertainty(localGridSquare, remoteGridSquare string) (*PathUncertainty, error) {
+	local, err := parseLocator(localGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local grid square: %w", err)
+	}
+	remote, err := parseLocator(remoteGridSquare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote grid square: %w", err)
+	}
+
+	localLat, localLon := local.center()
+	remoteLat, remoteLon := remote.center()
+	distance := haversineKm(localLat, localLon, remoteLat, remoteLon)
+	bearing := rawBearing(localLat, localLon, remoteLat, remoteLon)
+
+	minDist, maxDist := distance, distance
+	minDev, maxDev := 0.0, 0.0
+	localPoints := samplePoints(local.bounds())
+	remotePoints := samplePoints(remote.bounds())
+	for _, lp := range localPoints {
+		for _, rp := range remotePoints {
+			d := haversineKm(lp[0], lp[1], rp[0], rp[1])
+			minDist = math.Min(minDist, d)
+			maxDist = math.Max(maxDist, d)
+
+			// Deviation from the centre bearing in (-180, 180]
+			dev := math.Mod(rawBearing(lp[0], lp[1], rp[0], rp[1])-bearing+540, 360) - 180
+			minDev = math.Min(minDev, dev)
+			maxDev = math.Max(maxDev, dev)
+		}
+	}
+
+	short := PathRange{
+		DistanceKm:    math.Ceil(distance),
+		MinDistanceKm: math.Floor(minDist),
+		MaxDistanceKm: math.Ceil(maxDist),
+		Bearing:       roundBearing(bearing),
+		MinBearing:    roundBearing(bearing + minDev),
+		MaxBearing:    roundBearing(bearing + maxDev),
+	}
+	if cellsTouch(local.bounds(), remote.bounds()) {
+		minDist = 0
+		short.MinDistanceKm = 0
+		short.MinBearing, short.MaxBearing = 0, 360
+	}
+
+	// The long path is the remainder of the great circle, leaving in the opposite direction
+	circumference := 2 * math.Pi * earthRad
+	long := PathRange{
+		DistanceKm:    math.Ceil(circumference - distance),
+		MinDistanceKm: math.Floor(circumference - maxDist),
+		MaxDistanceKm: math.Ceil(circumference - minDist),
+		Bearing:       roundBearing(bearing + 180),
+		MinBearing:    roundBearing(short.MinBearing + 180),
+		MaxBearing:    roundBearing(short.MaxBearing + 180),
+	}
+	if short.MinBearing == 0 && short.MaxBearing == 360 {
+		long.MinBearing, long.MaxBearing = 0, 360
+	}
+
+	return &PathUncertainty{
+		LocalGridSquare:  localGridSquare,
+		RemoteGridSquare: remoteGridSquare,
+		ShortPath:        short,
+		LongPath:         long,
+	}, nil
+}
+
+// samplePoints returns points spaced along the edges of the cell, where distance and bearing extremes occur.
+func samplePoints(b BoundingBox) [][2]float64 {
+	n := uncertaintySamplesPerEdge
+	points := make([][2]float64, 0, 4*n)
+	for i := 0; i < n; i++ {
+		f := float64(i) / float64(n)
+		lat := b.MinLat + f*(b.MaxLat-b.MinLat)
+		lon := b.MinLon + f*(b.MaxLon-b.MinLon)
+		points = append(points,
+			[2]float64{b.MinLat, lon},                         // south edge, west to east
+			[2]float64{lat, b.MaxLon},                         // east edge, south to north
+			[2]float64{b.MaxLat, b.MaxLon - (lon - b.MinLon)}, // north edge, east to west
+			[2]float64{b.MaxLat - (lat - b.MinLat), b.MinLon}, // west edge, north to south
+		)
+	}
+	return points
+}
+
+// cellsTouch reports whether two cells share any point, including edges and corners and across the antimeridian.
+func cellsTouch(a, b BoundingBox) bool {
+	if a.MaxLat < b.MinLat || b.MaxLat < a.MinLat {
+		return false
+	}
+	for _, shift := range []float64{-360, 0, 360} {
+		if a.MinLon+shift <= b.MaxLon && b.MinLon <= a.MaxLon+shift {
+			return true
+		}
+	}
+	return false
+}
+
+// roundBearing normalizes a bearing into [0, 360) and rounds it to the nearest 0.1 degree.
+func roundBearing(bearing float64) float64 {
+	bearing = math.Mod(bearing, 360)
+	if bearing < 0 {
+		bearing += 360
+	}
+	bearing = math.Round(bearing*10) / 10
+	if bearing >= 360 {
+		bearing -= 360
+	}
+	return bearing
+}
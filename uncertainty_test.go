@@ -0,0 +1,100 @@
+package maidenhead
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGetPathUncertainty_SquareVsSubsquare(t *testing.T) {
+	fine, err := GetPathUncertainty("JN58td", "FN31pr")
+	if err != nil {
+		t.Fatalf("GetPathUncertainty error: %v", err)
+	}
+	coarse, err := GetPathUncertainty("JN58td", "FN31")
+	if err != nil {
+		t.Fatalf("GetPathUncertainty error: %v", err)
+	}
+
+	// Centre distance for subsquares must agree with GetShortPathDistance
+	km, _, _ := GetShortPathDistance("JN58td", "FN31pr")
+	if fine.ShortPath.DistanceKm != km {
+		t.Errorf("subsquare distance got %.0f want %.0f", fine.ShortPath.DistanceKm, km)
+	}
+	spb, _ := GetShortPathBearing("JN58td", "FN31pr")
+	if !almostEqual(fine.ShortPath.Bearing, spb, 0.1) {
+		t.Errorf("subsquare bearing got %.1f want %.1f", fine.ShortPath.Bearing, spb)
+	}
+
+	// Subsquares are ~9x5 km, so uncertainty should be a few km; a 2°x1° square gives tens of km
+	if u := fine.ShortPath.DistanceUncertaintyKm(); u < 1 || u > 15 {
+		t.Errorf("subsquare distance uncertainty got %.0f km, want a few km", u)
+	}
+	if u := coarse.ShortPath.DistanceUncertaintyKm(); u < 40 || u > 120 {
+		t.Errorf("square distance uncertainty got %.0f km, want tens of km", u)
+	}
+	if coarse.ShortPath.MinDistanceKm > coarse.ShortPath.DistanceKm || coarse.ShortPath.MaxDistanceKm < coarse.ShortPath.DistanceKm {
+		t.Errorf("distance bounds do not bracket centre: %+v", coarse.ShortPath)
+	}
+	if b := coarse.ShortPath.BearingUncertainty(); b <= fine.ShortPath.BearingUncertainty() || b > 5 {
+		t.Errorf("square bearing uncertainty got %.1f, fine %.1f", b, fine.ShortPath.BearingUncertainty())
+	}
+
+	// Long path mirrors the short path
+	circumference := 2 * math.Pi * earthRad
+	if math.Abs(coarse.LongPath.DistanceKm+coarse.ShortPath.DistanceKm-circumference) > 2 {
+		t.Errorf("long+short distance not circumference: %+v", coarse)
+	}
+	if !almostEqual(roundBearing(coarse.ShortPath.MinBearing+180), coarse.LongPath.MinBearing, 0.1) {
+		t.Errorf("long path bearing bounds not opposite: %+v", coarse)
+	}
+}
+
+func TestGetPathUncertainty_TouchingCells(t *testing.T) {
+	r, err := GetPathUncertainty("JN58", "JN58td")
+	if err != nil {
+		t.Fatalf("GetPathUncertainty error: %v", err)
+	}
+	if r.ShortPath.MinDistanceKm != 0 || r.ShortPath.MinBearing != 0 || r.ShortPath.MaxBearing != 360 {
+		t.Errorf("overlapping cells should have zero min distance and undetermined bearing: %+v", r.ShortPath)
+	}
+	if r.ShortPath.BearingUncertainty() != 180 {
+		t.Errorf("undetermined bearing uncertainty got %.1f want 180", r.ShortPath.BearingUncertainty())
+	}
+
+	// Adjacent across the antimeridian
+	r, err = GetPathUncertainty("AJ", "RJ")
+	if err != nil {
+		t.Fatalf("GetPathUncertainty error: %v", err)
+	}
+	if r.ShortPath.MinDistanceKm != 0 {
+		t.Errorf("antimeridian neighbours should have zero min distance: %+v", r.ShortPath)
+	}
+}
+
+func TestGetPathUncertainty_BearingWrap(t *testing.T) {
+	// Remote almost due north: bounds should straddle 0°
+	r, err := GetPathUncertainty("JJ00", "JN00")
+	if err != nil {
+		t.Fatalf("GetPathUncertainty error: %v", err)
+	}
+	if !(r.ShortPath.MinBearing > 300 && r.ShortPath.MaxBearing < 60) {
+		t.Errorf("expected bearing range wrapping through north, got %.1f..%.1f", r.ShortPath.MinBearing, r.ShortPath.MaxBearing)
+	}
+}
+
+func TestGetPathUncertainty_Errors(t *testing.T) {
+	if _, err := GetPathUncertainty("BAD", "JN58"); err == nil {
+		t.Errorf("expected error for bad local locator")
+	}
+	if _, err := GetPathUncertainty("JN58", "JN5"); err == nil {
+		t.Errorf("expected error for bad remote locator")
+	}
+}
+
+func TestGetPathUncertainty_MultibyteInput(t *testing.T) {
+	for _, in := range []string{"é", "éé", "JNé", "JN58tđ", "ĴN58td"} {
+		if _, err := GetPathUncertainty("JN58td", in); err == nil {
+			t.Errorf("GetPathUncertainty(%q): expected error", in)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package maidenhead
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	wmmEpoch       = 2025.0 // Base epoch of the embedded World Magnetic Model coefficients
+	wmmValidUntil  = 2030.0 // End of the model's five-year validity period
+	wmmDegree      = 12     // Maximum spherical harmonic degree and order
+	wmmReferenceKm = 6371.2 // Geomagnetic reference radius in kilometers
+)
+
+// wmmCoefficient is one row of the WMM coefficient file: Gauss coefficients in nT and their secular
+// variation in nT/year for degree n and order m.
+type wmmCoefficient struct {
+	n, m       int
+	g, h       float64
+	gDot, hDot float64
+}
+
+// wmmCoefficients are the World Magnetic Model 2025 (WMM2025) main field and secular variation coefficients,
+// published by NOAA NCEI and the British Geological Survey, valid from 2025.0 to 2030.0.
+var wmmCoefficients = []wmmCoefficient{
+	{1, 0, -29351.8, 0.0, 12.0, 0.0},
+	{1, 1, -1410.8, 4545.4, 9.7, -21.5},
+	{2, 0, -2556.6, 0.0, -11.6, 0.0},
+	{2, 1, 2951.1, -3133.6, -5.2, -27.7},
+	{2, 2, 1649.3, -815.1, -8.0, -12.1},
+	{3, 0, 1361.0, 0.0, -1.3, 0.0},
+	{3, 1, -2404.1, -56.6, -4.2, 4.0},
+	{3, 2, 1243.8, 237.5, 0.4, -0.3},
+	{3, 3, 453.6, -549.5, -15.6, -4.1},
+	{4, 0, 895.0, 0.0, -1.6, 0.0},
+	{4, 1, 799.5, 278.6, -2.4, -1.1},
+	{4, 2, 55.7, -133.9, -6.0, 4.1},
+	{4, 3, -281.1, 212.0, 5.6, 1.6},
+	{4, 4, 12.1, -375.6, -7.0, -4.4},
+	{5, 0, -233.2, 0.0, 0.6, 0.0},
+	{5, 1, 368.9, 45.4, 1.4, -0.5},
+	{5, 2, 187.2, 220.2, 0.0, 2.2},
+	{5, 3, -138.7, -122.9, 0.6, 0.4},
+	{5, 4, -142.0, 43.0, 2.2, 1.7},
+	{5, 5, 20.9, 106.1, 0.9, 1.9},
+	{6, 0, 64.4, 0.0, -0.2, 0.0},
+	{6, 1, 63.8, -18.4, -0.4, 0.3},
+	{6, 2, 76.9, 16.8, 0.9, -1.6},
+	{6, 3, -115.7, 48.8, 1.2, -0.4},
+	{6, 4, -40.9, -59.8, -0.9, 0.9},
+	{6, 5, 14.9, 10.9, 0.3, 0.7},
+	{6, 6, -60.7, 72.7, 0.9, 0.9},
+	{7, 0, 79.5, 0.0, 0.0, 0.0},
+	{7, 1, -77.0, -48.9, -0.1, 0.6},
+	{7, 2, -8.8, -14.4, -0.1, 0.5},
+	{7, 3, 59.3, -1.0, 0.5, -0.8},
+	{7, 4, 15.8, 23.4, -0.1, 0.0},
+	{7, 5, 2.5, -7.4, -0.8, -1.0},
+	{7, 6, -11.1, -25.1, -0.8, 0.6},
+	{7, 7, 14.2, -2.3, 0.8, -0.2},
+	{8, 0, 23.2, 0.0, -0.1, 0.0},
+	{8, 1, 10.8, 7.1, 0.2, -0.2},
+	{8, 2, -17.5, -12.6, 0.0, 0.5},
+	{8, 3, 2.0, 11.4, 0.5, -0.4},
+	{8, 4, -21.7, -9.7, -0.1, 0.4},
+	{8, 5, 16.9, 12.7, 0.3, -0.5},
+	{8, 6, 15.0, 0.7, 0.2, -0.6},
+	{8, 7, -16.8, -5.2, 0.0, 0.3},
+	{8, 8, 0.9, 3.9, 0.2, 0.2},
+	{9, 0, 4.6, 0.0, 0.0, 0.0},
+	{9, 1, 7.8, -24.8, -0.1, -0.3},
+	{9, 2, 3.0, 12.2, 0.1, 0.3},
+	{9, 3, -0.2, 8.3, 0.3, -0.3},
+	{9, 4, -2.5, -3.4, -0.3, 0.3},
+	{9, 5, -13.1, -5.3, 0.0, 0.2},
+	{9, 6, 2.4, 7.2, 0.3, -0.1},
+	{9, 7, 8.6, -0.6, -0.1, -0.2},
+	{9, 8, -8.7, 0.8, 0.1, 0.4},
+	{9, 9, -12.9, 10.0, -0.1, 0.1},
+	{10, 0, -1.3, 0.0, 0.1, 0.0},
+	{10, 1, -6.4, 3.3, 0.0, 0.0},
+	{10, 2, 0.2, 0.0, 0.1, 0.0},
+	{10, 3, 2.0, 2.4, 0.1, -0.2},
+	{10, 4, -1.0, 5.3, 0.0, 0.1},
+	{10, 5, -0.6, -9.1, -0.3, -0.1},
+	{10, 6, -0.9, 0.4, 0.0, 0.1},
+	{10, 7, 1.5, -4.2, -0.1, 0.0},
+	{10, 8, 0.9, -3.8, -0.1, -0.1},
+	{10, 9, -2.7, 0.9, 0.0, 0.2},
+	{10, 10, -3.9, -9.1, 0.0, 0.0},
+	{11, 0, 2.9, 0.0, 0.0, 0.0},
+	{11, 1, -1.5, 0.0, 0.0, 0.0},
+	{11, 2, -2.5, 2.9, 0.0, 0.1},
+	{11, 3, 2.4, -0.6, 0.0, 0.0},
+	{11, 4, -0.6, 0.2, 0.0, 0.1},
+	{11, 5, -0.1, 0.5, -0.1, 0.0},
+	{11, 6, -0.6, -0.3, 0.0, 0.0},
+	{11, 7, -0.1, -1.2, 0.0, 0.1},
+	{11, 8, 1.1, -1.7, -0.1, 0.0},
+	{11, 9, -1.0, -2.9, -0.1, 0.0},
+	{11, 10, -0.2, -1.8, -0.1, 0.0},
+	{11, 11, 2.6, -2.3, -0.1, 0.0},
+	{12, 0, -2.0, 0.0, 0.0, 0.0},
+	{12, 1, -0.2, -1.3, 0.0, 0.0},
+	{12, 2, 0.3, 0.7, 0.0, 0.0},
+	{12, 3, 1.2, 1.0, 0.0, -0.1},
+	{12, 4, -1.3, -1.4, 0.0, 0.1},
+	{12, 5, 0.6, -0.0, 0.0, 0.0},
+	{12, 6, 0.6, 0.6, 0.1, 0.0},
+	{12, 7, 0.5, -0.1, 0.0, 0.0},
+	{12, 8, -0.1, 0.8, 0.0, 0.0},
+	{12, 9, -0.4, 0.1, 0.0, 0.0},
+	{12, 10, -0.2, -1.0, -0.1, 0.0},
+	{12, 11, -1.3, 0.1, 0.0, 0.0},
+	{12, 12, -0.7, 0.2, -0.1, -0.1},
+}
+
+// magneticField evaluates the World Magnetic Model at sea level at geodetic (lat, lon) in degrees and returns the
+// north, east and down components of the field in nT.
+func magneticField(lat, lon float64, t time.Time) (float64, float64, float64) {
+	// Convert geodetic coordinates to geocentric spherical coordinates
+	phi := toRadians(lat)
+	lambda := toRadians(lon)
+	e2 := wgs84Flattening * (2 - wgs84Flattening)
+	rc := wgs84EquatorialRadKm / math.Sqrt(1-e2*math.Sin(phi)*math.Sin(phi))
+	p := rc * math.Cos(phi)
+	z := rc * (1 - e2) * math.Sin(phi)
+	r := math.Hypot(p, z)
+	phiC := math.Asin(z / r)
+
+	// Schmidt semi-normalized associated Legendre functions of cos(colatitude) and their derivatives
+	theta := math.Pi/2 - phiC
+	cosT, sinT := math.Cos(theta), math.Sin(theta)
+	if math.Abs(sinT) < 1e-10 {
+		sinT = 1e-10 // Avoid division by zero exactly at the geographic poles
+	}
+	var pnm, dpnm [wmmDegree + 1][wmmDegree + 1]float64
+	pnm[0][0] = 1
+	for n := 1; n <= wmmDegree; n++ {
+		for m := 0; m <= n; m++ {
+			if n == m {
+				k := 1.0
+				if n > 1 {
+					k = math.Sqrt(float64(2*n-1) / float64(2*n))
+				}
+				pnm[n][n] = k * sinT * pnm[n-1][n-1]
+				dpnm[n][n] = k * (sinT*dpnm[n-1][n-1] + cosT*pnm[n-1][n-1])
+				continue
+			}
+			a := float64(2*n-1) / math.Sqrt(float64(n*n-m*m))
+			pnm[n][m] = a * cosT * pnm[n-1][m]
+			dpnm[n][m] = a * (cosT*dpnm[n-1][m] - sinT*pnm[n-1][m])
+			if n > 1 {
+				b := math.Sqrt(float64((n-1)*(n-1)-m*m) / float64(n*n-m*m))
+				pnm[n][m] -= b * pnm[n-2][m]
+				dpnm[n][m] -= b * dpnm[n-2][m]
+			}
+		}
+	}
+
+	// Sum the spherical harmonic series for the radial, colatitude and longitude components
+	dt := decimalYear(t) - wmmEpoch
+	var br, bt, bp float64
+	for _, c := range wmmCoefficients {
+		g := c.g + c.gDot*dt
+		h := c.h + c.hDot*dt
+		ratio := math.Pow(wmmReferenceKm/r, float64(c.n+2))
+		cosM, sinM := math.Cos(float64(c.m)*lambda), math.Sin(float64(c.m)*lambda)
+		br += float64(c.n+1) * ratio * (g*cosM + h*sinM) * pnm[c.n][c.m]
+		bt -= ratio * (g*cosM + h*sinM) * dpnm[c.n][c.m]
+		bp += ratio * float64(c.m) * (g*sinM - h*cosM) * pnm[c.n][c.m] / sinT
+	}
+
+	// Rotate from geocentric to geodetic north/east/down
+	north, east, down := -bt, bp, -br
+	psi := phi - phiC
+	return north*math.Cos(psi) - down*math.Sin(psi), east, north*math.Sin(psi) + down*math.Cos(psi)
+}
+
+// wmmYear returns t as a decimal year, or an error if it falls outside the model's validity period.
+func wmmYear(t time.Time) (float64, error) {
+	year := decimalYear(t)
+	if year < wmmEpoch || year >= wmmValidUntil {
+		return 0, fmt.Errorf("date %s is outside the World Magnetic Model validity period (%.1f to %.1f)",
+			t.Format(time.DateOnly), wmmEpoch, wmmValidUntil)
+	}
+	return year, nil
+}
+
+// decimalYear returns t as a fractional year, e.g. 2025.0 at the start of 2025.
+func decimalYear(t time.Time) float64 {
+	t = t.UTC()
+	start := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	return float64(t.Year()) + t.Sub(start).Hours()/end.Sub(start).Hours()
+}